@@ -0,0 +1,265 @@
+// Package actor berisi runtime actor sederhana untuk rekening bank. Setiap rekening adalah
+// goroutine yang memiliki state-nya sendiri dan hanya bisa diubah lewat pesan di mailbox,
+// sehingga tidak ada lock yang dipegang bersamaan dan deadlock seperti pada Transfer tidak mungkin terjadi
+package actor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+)
+
+var (
+	// ErrInsufficientFunds dikembalikan ketika saldo yang tersedia tidak cukup
+	ErrInsufficientFunds = errors.New("actor: insufficient funds")
+	// ErrUnknownTransaction dikembalikan ketika Commit atau Abort merujuk transaksi yang tidak ada
+	ErrUnknownTransaction = errors.New("actor: unknown transaction")
+	// ErrCrashed dikembalikan ke pengirim pesan yang menyebabkan actor crash
+	ErrCrashed = errors.New("actor: actor crashed while handling message")
+	// ErrStopped dikembalikan ketika actor sudah berhenti
+	ErrStopped = errors.New("actor: actor stopped")
+	// ErrSameAccount dikembalikan Transfer ketika rekening asal dan tujuan sama
+	ErrSameAccount = errors.New("actor: transfer to the same account")
+)
+
+// Message adalah pesan yang dapat dikirim ke mailbox Account
+type Message interface {
+	reply(err error)
+}
+
+// Deposit menambah saldo sebesar Amount
+type Deposit struct {
+	Amount int
+	Reply  chan error
+}
+
+// Withdraw mengurangi saldo sebesar Amount jika saldo yang tersedia cukup
+type Withdraw struct {
+	Amount int
+	Reply  chan error
+}
+
+// GetBalance meminta saldo yang sudah di-commit
+type GetBalance struct {
+	Reply chan BalanceReply
+}
+
+// BalanceReply adalah balasan untuk GetBalance
+type BalanceReply struct {
+	Balance int
+	Err     error
+}
+
+// Reserve adalah fase pertama transfer: menahan Amount untuk transaksi Tx.
+// Amount negatif menahan dana keluar (ditolak jika saldo tidak cukup), positif mencatat dana masuk
+type Reserve struct {
+	Tx     uint64
+	Amount int
+	Reply  chan error
+}
+
+// Commit adalah fase kedua transfer: menerapkan dana yang ditahan untuk transaksi Tx
+type Commit struct {
+	Tx    uint64
+	Reply chan error
+}
+
+// Abort membatalkan dana yang ditahan untuk transaksi Tx
+type Abort struct {
+	Tx    uint64
+	Reply chan error
+}
+
+// Crash membuat actor panic, dipakai untuk menguji supervisi
+type Crash struct {
+	Reply chan error
+}
+
+func (message Deposit) reply(err error)  { trySend(message.Reply, err) }
+func (message Withdraw) reply(err error) { trySend(message.Reply, err) }
+func (message Reserve) reply(err error)  { trySend(message.Reply, err) }
+func (message Commit) reply(err error)   { trySend(message.Reply, err) }
+func (message Abort) reply(err error)    { trySend(message.Reply, err) }
+func (message Crash) reply(err error)    { trySend(message.Reply, err) }
+func (message GetBalance) reply(err error) {
+	trySend(message.Reply, BalanceReply{Err: err})
+}
+
+// trySend mengirim balasan tanpa menunggu. Balasan ke channel nil, atau ke channel tanpa buffer
+// yang tidak sedang ditunggu, dibuang agar satu pengirim tidak bisa menghentikan actor
+// untuk semua pemanggil lain
+func trySend[T any](reply chan T, value T) {
+	select {
+	case reply <- value:
+	default:
+	}
+}
+
+// State adalah state milik satu rekening
+type State struct {
+	Balance int            // Saldo yang sudah di-commit
+	Holds   map[uint64]int // Dana yang ditahan per transaksi
+}
+
+// Available mengembalikan saldo yang bisa dipakai, yaitu saldo dikurangi dana keluar yang ditahan
+func (state State) Available() int {
+	available := state.Balance
+	for _, amount := range state.Holds {
+		if amount < 0 {
+			available += amount
+		}
+	}
+	return available
+}
+
+func (state State) clone() State {
+	holds := make(map[uint64]int, len(state.Holds))
+	for tx, amount := range state.Holds {
+		holds[tx] = amount
+	}
+	return State{Balance: state.Balance, Holds: holds}
+}
+
+// Account adalah handle dari actor rekening. Semua method aman dipanggil dari banyak goroutine
+type Account struct {
+	Name string
+
+	mailbox chan Message
+	stopped chan struct{} // Ditutup ketika actor berhenti permanen
+
+	// state hanya diakses oleh goroutine supervise yang menjalankan actor, sehingga tidak perlu lock
+	state    State
+	restarts atomic.Int64
+}
+
+// Send mengirim message ke mailbox, menunggu jika mailbox penuh. Actor tidak pernah menunggu
+// saat membalas, sehingga channel Reply harus memiliki buffer minimal 1 agar balasan tidak dibuang.
+// Pesan yang masuk tepat sebelum actor berhenti tidak akan dibalas, sehingga pemanggil
+// juga perlu menunggu channel stopped seperti yang dilakukan call
+func (account *Account) Send(ctx context.Context, message Message) error {
+	select {
+	case <-account.stopped:
+		return ErrStopped
+	default:
+	}
+	select {
+	case account.mailbox <- message:
+		return nil
+	case <-account.stopped:
+		return ErrStopped
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// call mengirim message lalu menunggu balasan error dari channel reply
+func (account *Account) call(ctx context.Context, message Message, reply chan error) error {
+	if err := account.Send(ctx, message); err != nil {
+		return err
+	}
+	select {
+	case err := <-reply:
+		return err
+	case <-account.stopped:
+		return ErrStopped
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Deposit menambah saldo rekening
+func (account *Account) Deposit(ctx context.Context, amount int) error {
+	reply := make(chan error, 1)
+	return account.call(ctx, Deposit{Amount: amount, Reply: reply}, reply)
+}
+
+// Withdraw mengurangi saldo rekening
+func (account *Account) Withdraw(ctx context.Context, amount int) error {
+	reply := make(chan error, 1)
+	return account.call(ctx, Withdraw{Amount: amount, Reply: reply}, reply)
+}
+
+// Balance mengambil saldo rekening yang sudah di-commit
+func (account *Account) Balance(ctx context.Context) (int, error) {
+	reply := make(chan BalanceReply, 1)
+	if err := account.Send(ctx, GetBalance{Reply: reply}); err != nil {
+		return 0, err
+	}
+	select {
+	case result := <-reply:
+		return result.Balance, result.Err
+	case <-account.stopped:
+		return 0, ErrStopped
+	case <-ctx.Done():
+		return 0, ctx.Err()
+	}
+}
+
+// handle memproses satu pesan terhadap state dan mengirim balasan ke pengirim
+func (state *State) handle(message Message) {
+	switch message := message.(type) {
+	case Deposit:
+		state.Balance += message.Amount
+		message.reply(nil)
+	case Withdraw:
+		if state.Available() < message.Amount {
+			message.reply(ErrInsufficientFunds)
+			return
+		}
+		state.Balance -= message.Amount
+		message.reply(nil)
+	case GetBalance:
+		trySend(message.Reply, BalanceReply{Balance: state.Balance})
+	case Reserve:
+		if message.Amount < 0 && state.Available()+message.Amount < 0 {
+			message.reply(ErrInsufficientFunds)
+			return
+		}
+		state.Holds[message.Tx] = message.Amount
+		message.reply(nil)
+	case Commit:
+		amount, ok := state.Holds[message.Tx]
+		if !ok {
+			message.reply(ErrUnknownTransaction)
+			return
+		}
+		delete(state.Holds, message.Tx)
+		state.Balance += amount
+		message.reply(nil)
+	case Abort:
+		if _, ok := state.Holds[message.Tx]; !ok {
+			message.reply(ErrUnknownTransaction)
+			return
+		}
+		delete(state.Holds, message.Tx)
+		message.reply(nil)
+	case Crash:
+		panic("actor: crash requested")
+	default:
+		panic(fmt.Sprintf("actor: unknown message %T", message))
+	}
+}
+
+// run adalah loop goroutine actor. Setiap pesan diproses terhadap salinan state, dan salinan itu
+// baru menjadi state terakhir (checkpoint) jika pesan selesai tanpa panic
+func (account *Account) run(done <-chan struct{}) (crash error) {
+	var current Message
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			current.reply(ErrCrashed)
+			crash = fmt.Errorf("%s: %v", account.Name, recovered)
+		}
+	}()
+
+	for {
+		select {
+		case <-done:
+			return nil
+		case current = <-account.mailbox:
+			next := account.state.clone()
+			next.handle(current)
+			account.state = next
+		}
+	}
+}
@@ -0,0 +1,143 @@
+package actor
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+)
+
+// TestDeadlockScenario menjalankan skenario TestDeadlock (dua transfer berlawanan arah secara
+// bersamaan) berkali-kali dan memastikan semuanya selesai dalam batas waktu dengan saldo yang benar
+func TestDeadlockScenario(t *testing.T) {
+	supervisor := NewSupervisor(0)
+	defer supervisor.Stop()
+	user1 := supervisor.Spawn("Aidil", 1000000)
+	user2 := supervisor.Spawn("Budi", 1000000)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	group := sync.WaitGroup{}
+	for i := 0; i < 100; i++ {
+		group.Add(2)
+		go func() {
+			defer group.Done()
+			if err := Transfer(ctx, user1, user2, 100); err != nil {
+				t.Error(err)
+			}
+		}()
+		go func() {
+			defer group.Done()
+			if err := Transfer(ctx, user2, user1, 200); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	group.Wait()
+
+	balance1, _ := user1.Balance(ctx)
+	balance2, _ := user2.Balance(ctx)
+	if balance1 != 1010000 || balance2 != 990000 {
+		t.Fatalf("saldo = %d dan %d", balance1, balance2)
+	}
+}
+
+// TestInsufficientFunds memastikan transfer yang gagal di fase reserve tidak mengubah saldo
+func TestInsufficientFunds(t *testing.T) {
+	supervisor := NewSupervisor(0)
+	defer supervisor.Stop()
+	user1 := supervisor.Spawn("Aidil", 100)
+	user2 := supervisor.Spawn("Budi", 0)
+	ctx := context.Background()
+
+	if err := Transfer(ctx, user1, user2, 150); !errors.Is(err, ErrInsufficientFunds) {
+		t.Fatalf("err = %v", err)
+	}
+	if err := user1.Withdraw(ctx, 150); !errors.Is(err, ErrInsufficientFunds) {
+		t.Fatalf("err = %v", err)
+	}
+	balance1, _ := user1.Balance(ctx)
+	balance2, _ := user2.Balance(ctx)
+	if balance1 != 100 || balance2 != 0 {
+		t.Fatalf("saldo = %d dan %d", balance1, balance2)
+	}
+}
+
+// TestSelfTransfer memastikan transfer ke rekening yang sama ditolak tanpa mengubah saldo
+func TestSelfTransfer(t *testing.T) {
+	supervisor := NewSupervisor(0)
+	defer supervisor.Stop()
+	account := supervisor.Spawn("Aidil", 100)
+	ctx := context.Background()
+
+	if err := Transfer(ctx, account, account, 50); !errors.Is(err, ErrSameAccount) {
+		t.Fatalf("err = %v", err)
+	}
+	if balance, _ := account.Balance(ctx); balance != 100 {
+		t.Fatalf("saldo = %d", balance)
+	}
+}
+
+// TestUnbufferedReply memastikan pesan dengan channel Reply nil atau tanpa buffer
+// tidak menghentikan actor untuk pemanggil lain
+func TestUnbufferedReply(t *testing.T) {
+	supervisor := NewSupervisor(0)
+	defer supervisor.Stop()
+	account := supervisor.Spawn("Aidil", 100)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := account.Send(ctx, Deposit{Amount: 10}); err != nil {
+		t.Fatal(err)
+	}
+	if err := account.Send(ctx, Deposit{Amount: 10, Reply: make(chan error)}); err != nil {
+		t.Fatal(err)
+	}
+	if balance, err := account.Balance(ctx); err != nil || balance != 120 {
+		t.Fatalf("saldo = %d, err = %v", balance, err)
+	}
+}
+
+// TestSupervisorRestart memastikan actor yang crash di-restart dengan state terakhirnya
+func TestSupervisorRestart(t *testing.T) {
+	supervisor := NewSupervisor(1)
+	defer supervisor.Stop()
+	account := supervisor.Spawn("Aidil", 100)
+	ctx := context.Background()
+
+	account.Deposit(ctx, 50)
+	reply := make(chan error, 1)
+	if err := account.call(ctx, Crash{Reply: reply}, reply); !errors.Is(err, ErrCrashed) {
+		t.Fatalf("err = %v", err)
+	}
+
+	balance, err := account.Balance(ctx)
+	if err != nil || balance != 150 {
+		t.Fatalf("saldo setelah restart = %d, err = %v", balance, err)
+	}
+	if account.Restarts() != 1 || len(supervisor.Crashes()) != 1 {
+		t.Fatalf("restarts = %d, crashes = %v", account.Restarts(), supervisor.Crashes())
+	}
+
+	// Restart sudah mencapai batas, crash berikutnya menghentikan actor secara permanen
+	account.call(ctx, Crash{Reply: reply}, reply)
+	if err := account.Deposit(ctx, 1); !errors.Is(err, ErrStopped) {
+		t.Fatalf("err = %v", err)
+	}
+}
+
+// TestStop memastikan Stop menghentikan semua goroutine actor
+func TestStop(t *testing.T) {
+	before := runtime.NumGoroutine()
+	supervisor := NewSupervisor(0)
+	for i := 0; i < 10; i++ {
+		supervisor.Spawn("Aidil", 0)
+	}
+	supervisor.Stop()
+	if after := runtime.NumGoroutine(); after > before {
+		t.Fatalf("goroutine bocor: sebelum %d, sesudah %d", before, after)
+	}
+}
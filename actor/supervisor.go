@@ -0,0 +1,78 @@
+package actor
+
+import (
+	"sync"
+)
+
+// Supervisor menjalankan actor dan me-restart actor yang crash dari state terakhirnya
+type Supervisor struct {
+	maxRestarts int
+	done        chan struct{}
+	group       sync.WaitGroup
+	stopOnce    sync.Once
+
+	mutex   sync.Mutex
+	crashes []error
+}
+
+// NewSupervisor membuat supervisor yang me-restart setiap actor maksimal maxRestarts kali
+func NewSupervisor(maxRestarts int) *Supervisor {
+	return &Supervisor{maxRestarts: maxRestarts, done: make(chan struct{})}
+}
+
+// Spawn membuat actor rekening baru dengan saldo awal balance
+func (supervisor *Supervisor) Spawn(name string, balance int) *Account {
+	account := &Account{
+		Name:    name,
+		mailbox: make(chan Message, 16),
+		stopped: make(chan struct{}),
+		state:   State{Balance: balance, Holds: map[uint64]int{}},
+	}
+
+	supervisor.group.Add(1)
+	go supervisor.supervise(account)
+	return account
+}
+
+// supervise menjalankan actor dan menjalankannya kembali setiap kali crash.
+// Karena loop ini berjalan di goroutine yang sama, state actor berpindah tangan tanpa data race
+func (supervisor *Supervisor) supervise(account *Account) {
+	defer supervisor.group.Done()
+	defer close(account.stopped)
+
+	for {
+		crash := account.run(supervisor.done)
+		if crash == nil {
+			return
+		}
+
+		supervisor.mutex.Lock()
+		supervisor.crashes = append(supervisor.crashes, crash)
+		supervisor.mutex.Unlock()
+
+		if int(account.restarts.Load()) >= supervisor.maxRestarts {
+			return
+		}
+		account.restarts.Add(1)
+	}
+}
+
+// Crashes mengembalikan daftar crash yang pernah ditangani supervisor
+func (supervisor *Supervisor) Crashes() []error {
+	supervisor.mutex.Lock()
+	defer supervisor.mutex.Unlock()
+	return append([]error(nil), supervisor.crashes...)
+}
+
+// Stop menghentikan semua actor dan menunggu goroutine-nya selesai
+func (supervisor *Supervisor) Stop() {
+	supervisor.stopOnce.Do(func() {
+		close(supervisor.done)
+	})
+	supervisor.group.Wait()
+}
+
+// Restarts mengembalikan berapa kali actor ini sudah di-restart oleh supervisor
+func (account *Account) Restarts() int {
+	return int(account.restarts.Load())
+}
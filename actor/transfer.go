@@ -0,0 +1,52 @@
+package actor
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+)
+
+// nextTx adalah penghitung ID transaksi untuk Transfer
+var nextTx atomic.Uint64
+
+// Transfer memindahkan amount dari from ke to dengan protokol dua fase:
+//  1. Reserve: from menahan dana keluar dan to mencatat dana masuk
+//  2. Commit jika kedua reserve berhasil, atau Abort untuk reserve yang sudah berhasil
+//
+// Koordinator tidak pernah memegang apapun sambil menunggu, dan setiap actor langsung
+// membalas pesan tanpa menunggu actor lain, sehingga transfer berlawanan arah tidak bisa deadlock.
+// Dana ditahan per transaksi, sehingga transfer ke rekening yang sama ditolak dengan ErrSameAccount
+func Transfer(ctx context.Context, from *Account, to *Account, amount int) error {
+	if from == to {
+		return ErrSameAccount
+	}
+	tx := nextTx.Add(1)
+
+	fromReply := make(chan error, 1)
+	if err := from.call(ctx, Reserve{Tx: tx, Amount: -amount, Reply: fromReply}, fromReply); err != nil {
+		// Reserve mungkin sudah diproses walaupun pemanggil berhenti menunggu, jadi tetap dibatalkan
+		return errors.Join(err, finish(from, tx, false))
+	}
+
+	toReply := make(chan error, 1)
+	if err := to.call(ctx, Reserve{Tx: tx, Amount: amount, Reply: toReply}, toReply); err != nil {
+		return errors.Join(err, finish(from, tx, false), finish(to, tx, false))
+	}
+
+	return errors.Join(finish(from, tx, true), finish(to, tx, true))
+}
+
+// finish mengirim Commit atau Abort tanpa context pemanggil, karena fase kedua harus
+// tetap diselesaikan walaupun pemanggil sudah berhenti menunggu.
+// Abort untuk transaksi yang tidak pernah di-reserve tidak dianggap error
+func finish(account *Account, tx uint64, commit bool) error {
+	reply := make(chan error, 1)
+	if commit {
+		return account.call(context.Background(), Commit{Tx: tx, Reply: reply}, reply)
+	}
+	err := account.call(context.Background(), Abort{Tx: tx, Reply: reply}, reply)
+	if errors.Is(err, ErrUnknownTransaction) {
+		return nil
+	}
+	return err
+}
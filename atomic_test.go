@@ -2,10 +2,17 @@
 package belajar_golang_goroutines
 
 import (
+	"belajar-golang-goroutines/bank"
+	"belajar-golang-goroutines/debug"
+	"belajar-golang-goroutines/syncx"
+	"belajar-golang-goroutines/timex"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"sync"
 	"sync/atomic"
 	"testing"
+	"time"
 )
 
 // TestAtomic menguji penggunaan atomic operation untuk menghindari race condition
@@ -37,3 +44,41 @@ func TestAtomic(t *testing.T) {
 	// Menampilkan hasil akhir counter
 	fmt.Println("Counter = ", x)
 }
+
+// TestDebugHandler mendaftarkan counter atomic, pool, rekening, dan timer ke debug.Registry,
+// lalu menampilkan output JSON dan Prometheus-nya lewat httptest tanpa membuka port
+func TestDebugHandler(t *testing.T) {
+	var x atomic.Int64
+	pool := &syncx.InstrumentedPool{New: func() any { return "New" }}
+	account := &bank.InstrumentedAccount{}
+	account.RWMutex.Name = "account"
+	timers := &timex.TimerGroup{}
+
+	group := sync.WaitGroup{}
+	for i := 0; i < 100; i++ {
+		group.Add(1)
+		go func() {
+			defer group.Done()
+			x.Add(1)
+			pool.Put(pool.Get())
+			account.AddBalance(1)
+		}()
+	}
+	group.Wait()
+	timer := timers.AfterFunc(time.Minute, func() {})
+	defer timer.Stop()
+
+	registry := debug.New()
+	registry.AddCounter("x", x.Load)
+	registry.AddPool("string", pool)
+	registry.AddLock(&account.RWMutex)
+	registry.AddAccount("account", account)
+	registry.AddTimers("demo", timers)
+
+	for _, target := range []string{"/debug/vars", "/metrics"} {
+		recorder := httptest.NewRecorder()
+		registry.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, target, nil))
+		fmt.Println("GET", target)
+		fmt.Println(recorder.Body.String())
+	}
+}
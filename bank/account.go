@@ -0,0 +1,92 @@
+// Package bank berisi rekening bank yang dipakai contoh-contoh mutex, RWMutex, dan deadlock.
+// Semua dependensi seperti tujuan output dan lama proses diberikan lewat parameter,
+// sehingga package ini dapat dipakai dari package lain tanpa variabel global
+package bank
+
+import (
+	"belajar-golang-goroutines/syncx"
+	"sync"
+)
+
+// Account merepresentasikan rekening bank dengan RWMutex untuk mengamankan akses concurrent
+type Account struct {
+	RWMutex sync.RWMutex // RWMutex untuk membedakan operasi read dan write
+	Balance int          // Saldo rekening
+	lockID               // Urutan penguncian untuk SnapshotAccounts dan MoveBalance
+}
+
+// AddBalance menambahkan sejumlah amount ke saldo rekening dengan menggunakan write lock
+func (account *Account) AddBalance(amount int) {
+	account.RWMutex.Lock()
+	account.Balance = account.Balance + amount
+	account.RWMutex.Unlock()
+}
+
+// GetBalance mengambil nilai saldo rekening dengan menggunakan read lock
+func (account *Account) GetBalance() int {
+	account.RWMutex.RLock()
+	balance := account.Balance
+	account.RWMutex.RUnlock()
+	return balance
+}
+
+// MutexAccount adalah versi Account yang memakai sync.Mutex biasa,
+// digunakan sebagai pembanding untuk mengukur keuntungan RWMutex
+type MutexAccount struct {
+	Mutex   sync.Mutex // Mutex yang mengunci operasi read maupun write
+	Balance int        // Saldo rekening
+}
+
+// AddBalance menambahkan sejumlah amount ke saldo rekening
+func (account *MutexAccount) AddBalance(amount int) {
+	account.Mutex.Lock()
+	account.Balance = account.Balance + amount
+	account.Mutex.Unlock()
+}
+
+// GetBalance mengambil nilai saldo rekening, tetap menggunakan lock eksklusif
+func (account *MutexAccount) GetBalance() int {
+	account.Mutex.Lock()
+	balance := account.Balance
+	account.Mutex.Unlock()
+	return balance
+}
+
+// InstrumentedAccount adalah versi Account yang memakai syncx.InstrumentedRWMutex,
+// sehingga jumlah reader dan goroutine yang menunggu dapat dilihat dari State
+type InstrumentedAccount struct {
+	RWMutex syncx.InstrumentedRWMutex // RWMutex yang mencatat metric dan keadaan lock
+	Balance int                       // Saldo rekening
+}
+
+// AddBalance menambahkan sejumlah amount ke saldo rekening dengan menggunakan write lock
+func (account *InstrumentedAccount) AddBalance(amount int) {
+	account.RWMutex.Lock()
+	account.Balance = account.Balance + amount
+	account.RWMutex.Unlock()
+}
+
+// GetBalance mengambil nilai saldo rekening dengan menggunakan read lock
+func (account *InstrumentedAccount) GetBalance() int {
+	account.RWMutex.RLock()
+	balance := account.Balance
+	account.RWMutex.RUnlock()
+	return balance
+}
+
+// SeqAccount adalah versi Account yang memakai syncx.SeqLock. GetBalance tidak mengunci
+// dan tidak menulis ke memori bersama, sehingga cocok untuk saldo yang jauh lebih sering dibaca
+// daripada diubah. Zero value siap dipakai dengan saldo 0
+type SeqAccount struct {
+	Balance syncx.SeqLock[int] // Saldo rekening
+}
+
+// AddBalance menambahkan sejumlah amount ke saldo rekening, writer lain menunggu sampai selesai
+func (account *SeqAccount) AddBalance(amount int) {
+	account.Balance.Update(func(balance int) int { return balance + amount })
+}
+
+// GetBalance mengambil nilai saldo rekening tanpa lock, mengulang jika bertabrakan dengan AddBalance
+func (account *SeqAccount) GetBalance() int {
+	return account.Balance.Load()
+}
@@ -0,0 +1,115 @@
+package bank
+
+import (
+	"io"
+	"sync"
+	"testing"
+	"time"
+)
+
+// TestAccount memastikan AddBalance dari banyak goroutine tidak kehilangan update
+func TestAccount(t *testing.T) {
+	account := Account{}
+	mutexAccount := MutexAccount{}
+	group := sync.WaitGroup{}
+	for i := 0; i < 100; i++ {
+		group.Add(1)
+		go func() {
+			defer group.Done()
+			for j := 0; j < 100; j++ {
+				account.AddBalance(1)
+				mutexAccount.AddBalance(1)
+				account.GetBalance()
+			}
+		}()
+	}
+	group.Wait()
+
+	if account.GetBalance() != 10000 || mutexAccount.GetBalance() != 10000 {
+		t.Fatalf("saldo = %d dan %d", account.GetBalance(), mutexAccount.GetBalance())
+	}
+}
+
+// TestSeqAccount memastikan SeqAccount tidak kehilangan update dan GetBalance tanpa lock
+// tidak pernah melihat saldo yang turun
+func TestSeqAccount(t *testing.T) {
+	account := SeqAccount{}
+	group := sync.WaitGroup{}
+	for i := 0; i < 100; i++ {
+		group.Add(1)
+		go func() {
+			defer group.Done()
+			last := 0
+			for j := 0; j < 100; j++ {
+				account.AddBalance(1)
+				balance := account.GetBalance()
+				if balance < last {
+					t.Errorf("saldo turun dari %d ke %d", last, balance)
+				}
+				last = balance
+			}
+		}()
+	}
+	group.Wait()
+
+	if account.GetBalance() != 10000 {
+		t.Fatalf("saldo = %d", account.GetBalance())
+	}
+}
+
+// TestTransferWithBackoff menjalankan dua transfer berlawanan arah yang akan deadlock dengan
+// Transfer, dan memastikan keduanya selesai dengan saldo yang benar
+func TestTransferWithBackoff(t *testing.T) {
+	user1 := &TimedUserBalance{Name: "Aidil", Balance: 1000000}
+	user2 := &TimedUserBalance{Name: "Budi", Balance: 1000000}
+
+	group := sync.WaitGroup{}
+	group.Add(2)
+	go func() {
+		defer group.Done()
+		TransferWithBackoff(io.Discard, user1, user2, 100000, 5*time.Millisecond, 2*time.Millisecond)
+	}()
+	go func() {
+		defer group.Done()
+		TransferWithBackoff(io.Discard, user2, user1, 200000, 5*time.Millisecond, 2*time.Millisecond)
+	}()
+	group.Wait()
+
+	if user1.Balance != 1100000 || user2.Balance != 900000 {
+		t.Fatalf("saldo tidak sesuai: %d dan %d", user1.Balance, user2.Balance)
+	}
+}
+
+// balanceAccount adalah method yang dimiliki semua versi Account
+type balanceAccount interface {
+	AddBalance(amount int)
+	GetBalance() int
+}
+
+// BenchmarkAccount membandingkan Account (RWMutex), MutexAccount, dan SeqAccount dengan
+// 1 AddBalance per 100 operasi dari banyak goroutine
+func BenchmarkAccount(b *testing.B) {
+	accounts := []struct {
+		name    string
+		account balanceAccount
+	}{
+		{"rwmutex", &Account{}},
+		{"mutex", &MutexAccount{}},
+		{"seqlock", &SeqAccount{}},
+	}
+	for _, account := range accounts {
+		b.Run(account.name, func(b *testing.B) {
+			b.RunParallel(func(pb *testing.PB) {
+				i := 0
+				for pb.Next() {
+					if i%100 == 0 {
+						account.account.AddBalance(1)
+					} else {
+						account.account.GetBalance()
+					}
+					i++
+				}
+			})
+		})
+	}
+}
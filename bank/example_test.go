@@ -0,0 +1,71 @@
+package bank_test
+
+import (
+	"belajar-golang-goroutines/bank"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// ExampleAccount adalah versi TestRWMutex: 100 goroutine menambah dan membaca saldo bersamaan
+func ExampleAccount() {
+	account := bank.Account{}
+	group := sync.WaitGroup{}
+	for i := 0; i < 100; i++ {
+		group.Add(1)
+		go func() {
+			defer group.Done()
+			for j := 0; j < 100; j++ {
+				account.AddBalance(1)
+				account.GetBalance()
+			}
+		}()
+	}
+	group.Wait()
+	fmt.Println("Total Balance", account.GetBalance())
+	// Output: Total Balance 10000
+}
+
+// ExampleTransfer menjalankan satu transfer. Dua Transfer berlawanan arah yang berjalan
+// bersamaan dapat deadlock seperti pada TestDeadlock
+func ExampleTransfer() {
+	user1 := &bank.UserBalance{Name: "Aidil", Balance: 1000000}
+	user2 := &bank.UserBalance{Name: "Budi", Balance: 1000000}
+
+	bank.Transfer(os.Stdout, user1, user2, 100000, time.Millisecond)
+
+	fmt.Println("User", user1.Name, "Balance", user1.Balance)
+	fmt.Println("User", user2.Name, "Balance", user2.Balance)
+	// Output:
+	// Lock user1 Aidil
+	// Lock user2 Budi
+	// User Aidil Balance 900000
+	// User Budi Balance 1100000
+}
+
+// ExampleTransferWithBackoff adalah versi TestDeadlock yang tidak deadlock
+func ExampleTransferWithBackoff() {
+	user1 := &bank.TimedUserBalance{Name: "Aidil", Balance: 1000000}
+	user2 := &bank.TimedUserBalance{Name: "Budi", Balance: 1000000}
+
+	// Log percobaan tidak ditampilkan karena urutannya acak
+	group := sync.WaitGroup{}
+	group.Add(2)
+	go func() {
+		defer group.Done()
+		bank.TransferWithBackoff(io.Discard, user1, user2, 100000, 5*time.Millisecond, 2*time.Millisecond)
+	}()
+	go func() {
+		defer group.Done()
+		bank.TransferWithBackoff(io.Discard, user2, user1, 200000, 5*time.Millisecond, 2*time.Millisecond)
+	}()
+	group.Wait()
+
+	fmt.Println("User", user1.Name, "Balance", user1.Balance)
+	fmt.Println("User", user2.Name, "Balance", user2.Balance)
+	// Output:
+	// User Aidil Balance 1100000
+	// User Budi Balance 900000
+}
@@ -0,0 +1,332 @@
+package bank
+
+import (
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+)
+
+// Nama file di dalam direktori journal
+const (
+	journalLog      = "journal.log"
+	journalSnapshot = "snapshot"
+)
+
+// recordSize adalah ukuran satu record log dan file snapshot:
+// sequence (8 byte), nilai (8 byte), dan checksum CRC-32C (4 byte), semuanya little endian
+const recordSize = 20
+
+// ErrJournalClosed dikembalikan ketika AddBalance dipanggil setelah Close
+var ErrJournalClosed = errors.New("bank: journal sudah ditutup")
+
+var crcTable = crc32.MakeTable(crc32.Castagnoli)
+
+// JournalOptions mengatur JournaledAccount
+type JournalOptions struct {
+	MaxBatch      int // Jumlah record maksimal dalam satu fsync, default 128
+	SnapshotEvery int // Snapshot dibuat dan log dikosongkan setiap sekian record, 0 berarti tidak pernah
+
+	syncFile func(*os.File) error // fsync log, default (*os.File).Sync, diganti test untuk mensimulasikan disk lambat
+}
+
+// JournalStats adalah statistik journal sejak dibuka
+type JournalStats struct {
+	Records   int64 // Record yang ditulis ke log
+	Syncs     int64 // Jumlah fsync log, lebih kecil dari Records jika group commit bekerja
+	Snapshots int64 // Snapshot yang dibuat
+	Recovered int64 // Record log yang diputar ulang ketika dibuka
+}
+
+// journalRequest adalah satu AddBalance yang menunggu giliran ditulis oleh flusher
+type journalRequest struct {
+	amount int
+	done   chan error
+}
+
+// JournaledAccount adalah Account yang setiap AddBalance-nya ditulis ke write-ahead log sebelum
+// saldo di memori berubah. Penulisan dari banyak goroutine digabung oleh satu goroutine flusher
+// menjadi satu fsync (group commit), sehingga throughput tetap tinggi meski setiap operasi durable
+type JournaledAccount struct {
+	account  Account
+	dir      string
+	options  JournalOptions
+	log      *os.File
+	sequence uint64 // Sequence record terakhir, hanya diakses flusher setelah dibuka
+	pending  int    // Record sejak snapshot terakhir, hanya diakses flusher
+
+	closeMutex sync.RWMutex
+	closed     bool
+	requests   chan journalRequest
+	flushed    chan struct{}
+	err        error // Error tulis yang membuat journal berhenti, hanya diakses flusher
+
+	records   atomic.Int64
+	syncs     atomic.Int64
+	snapshots atomic.Int64
+	recovered int64
+}
+
+// OpenJournaledAccount membuka journal di dir (dibuat jika belum ada), memulihkan saldo dari
+// snapshot dan log ke Account baru, lalu menjalankan goroutine flusher. Record di ujung log
+// yang terpotong atau checksum-nya salah, misalnya karena crash saat menulis, dibuang
+func OpenJournaledAccount(dir string, options JournalOptions) (*JournaledAccount, error) {
+	if options.MaxBatch <= 0 {
+		options.MaxBatch = 128
+	}
+	if options.syncFile == nil {
+		options.syncFile = (*os.File).Sync
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	journal := &JournaledAccount{
+		dir:      dir,
+		options:  options,
+		requests: make(chan journalRequest),
+		flushed:  make(chan struct{}),
+	}
+	if err := journal.recover(); err != nil {
+		return nil, err
+	}
+	go journal.flusher()
+	return journal, nil
+}
+
+// recover membaca snapshot lalu memutar ulang log. Record dengan sequence yang sudah tercakup
+// snapshot dilewati, karena crash bisa terjadi setelah snapshot ditulis tetapi sebelum log dikosongkan
+func (journal *JournaledAccount) recover() error {
+	snapshot, err := os.ReadFile(filepath.Join(journal.dir, journalSnapshot))
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+	case err != nil:
+		return err
+	default:
+		sequence, balance, ok := decodeRecord(snapshot)
+		if !ok {
+			return errors.New("bank: snapshot rusak")
+		}
+		journal.sequence = sequence
+		journal.account.Balance = int(balance)
+	}
+
+	log, err := os.OpenFile(filepath.Join(journal.dir, journalLog), os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return err
+	}
+	data, err := io.ReadAll(log)
+	if err != nil {
+		log.Close()
+		return err
+	}
+
+	valid := 0
+	for ; valid+recordSize <= len(data); valid += recordSize {
+		sequence, amount, ok := decodeRecord(data[valid : valid+recordSize])
+		if !ok || sequence > journal.sequence+1 {
+			break
+		}
+		if sequence <= journal.sequence {
+			continue
+		}
+		journal.sequence = sequence
+		journal.account.Balance += int(amount)
+		journal.pending++
+		journal.recovered++
+	}
+
+	// Buang sisa log yang rusak agar record baru tidak ditulis setelah sampah
+	if valid < len(data) {
+		if err := log.Truncate(int64(valid)); err != nil {
+			log.Close()
+			return err
+		}
+	}
+	if _, err := log.Seek(int64(valid), io.SeekStart); err != nil {
+		log.Close()
+		return err
+	}
+	journal.log = log
+	return nil
+}
+
+// AddBalance menulis amount ke log, menunggu fsync, lalu menambahkannya ke saldo.
+// Jika error dikembalikan, saldo tidak berubah
+func (journal *JournaledAccount) AddBalance(amount int) error {
+	journal.closeMutex.RLock()
+	defer journal.closeMutex.RUnlock()
+	if journal.closed {
+		return ErrJournalClosed
+	}
+
+	request := journalRequest{amount: amount, done: make(chan error, 1)}
+	journal.requests <- request
+	return <-request.done
+}
+
+// GetBalance mengambil saldo yang sudah durable
+func (journal *JournaledAccount) GetBalance() int {
+	return journal.account.GetBalance()
+}
+
+// Stats mengembalikan statistik journal saat ini
+func (journal *JournaledAccount) Stats() JournalStats {
+	return JournalStats{
+		Records:   journal.records.Load(),
+		Syncs:     journal.syncs.Load(),
+		Snapshots: journal.snapshots.Load(),
+		Recovered: journal.recovered,
+	}
+}
+
+// Close menunggu AddBalance yang sedang berjalan, menghentikan flusher, dan menutup log
+func (journal *JournaledAccount) Close() error {
+	journal.closeMutex.Lock()
+	if journal.closed {
+		journal.closeMutex.Unlock()
+		return ErrJournalClosed
+	}
+	journal.closed = true
+	close(journal.requests)
+	journal.closeMutex.Unlock()
+
+	<-journal.flushed
+	return journal.log.Close()
+}
+
+// flusher mengambil satu request, lalu semua request lain yang sudah menunggu, dan menulisnya
+// dengan satu Write dan satu fsync
+func (journal *JournaledAccount) flusher() {
+	defer close(journal.flushed)
+	batch := make([]journalRequest, 0, journal.options.MaxBatch)
+	buffer := make([]byte, 0, journal.options.MaxBatch*recordSize)
+	for first := range journal.requests {
+		batch = append(batch[:0], first)
+	collect:
+		for len(batch) < journal.options.MaxBatch {
+			select {
+			case request, ok := <-journal.requests:
+				if !ok {
+					break collect
+				}
+				batch = append(batch, request)
+			default:
+				break collect
+			}
+		}
+
+		err := journal.err
+		if err == nil {
+			buffer = buffer[:0]
+			for i, request := range batch {
+				buffer = appendRecord(buffer, journal.sequence+uint64(i)+1, int64(request.amount))
+			}
+			err = journal.write(buffer)
+		}
+		if err != nil {
+			// Isi log setelah write yang gagal tidak diketahui, sehingga journal berhenti menerima
+			// perubahan. Membuka ulang journal akan membuang record yang tidak lengkap
+			journal.err = err
+			for _, request := range batch {
+				request.done <- err
+			}
+			continue
+		}
+
+		journal.sequence += uint64(len(batch))
+		journal.pending += len(batch)
+		journal.records.Add(int64(len(batch)))
+		for _, request := range batch {
+			journal.account.AddBalance(request.amount)
+			request.done <- nil
+		}
+
+		if journal.options.SnapshotEvery > 0 && journal.pending >= journal.options.SnapshotEvery {
+			if err := journal.snapshot(); err != nil {
+				journal.err = err
+			}
+		}
+	}
+}
+
+// write menulis buffer ke log lalu menunggu fsync
+func (journal *JournaledAccount) write(buffer []byte) error {
+	if _, err := journal.log.Write(buffer); err != nil {
+		return err
+	}
+	journal.syncs.Add(1)
+	return journal.options.syncFile(journal.log)
+}
+
+// snapshot menulis saldo dan sequence terakhir ke file sementara, menggantinya secara atomik
+// dengan rename, lalu mengosongkan log. Hanya dipanggil oleh flusher
+func (journal *JournaledAccount) snapshot() error {
+	path := filepath.Join(journal.dir, journalSnapshot)
+	temporary := path + ".tmp"
+	file, err := os.Create(temporary)
+	if err != nil {
+		return err
+	}
+	record := appendRecord(nil, journal.sequence, int64(journal.account.GetBalance()))
+	if _, err := file.Write(record); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Sync(); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(temporary, path); err != nil {
+		return err
+	}
+	if err := syncDir(journal.dir); err != nil {
+		return err
+	}
+
+	if err := journal.log.Truncate(0); err != nil {
+		return err
+	}
+	if _, err := journal.log.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	journal.pending = 0
+	journal.snapshots.Add(1)
+	return nil
+}
+
+// syncDir memastikan rename di dalam dir sudah durable
+func syncDir(dir string) error {
+	directory, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer directory.Close()
+	return directory.Sync()
+}
+
+// appendRecord menambahkan satu record ke buffer
+func appendRecord(buffer []byte, sequence uint64, value int64) []byte {
+	start := len(buffer)
+	buffer = binary.LittleEndian.AppendUint64(buffer, sequence)
+	buffer = binary.LittleEndian.AppendUint64(buffer, uint64(value))
+	return binary.LittleEndian.AppendUint32(buffer, crc32.Checksum(buffer[start:], crcTable))
+}
+
+// decodeRecord membaca satu record, ok bernilai false jika ukuran atau checksum salah
+func decodeRecord(record []byte) (sequence uint64, value int64, ok bool) {
+	if len(record) != recordSize {
+		return 0, 0, false
+	}
+	if crc32.Checksum(record[:16], crcTable) != binary.LittleEndian.Uint32(record[16:]) {
+		return 0, 0, false
+	}
+	return binary.LittleEndian.Uint64(record), int64(binary.LittleEndian.Uint64(record[8:])), true
+}
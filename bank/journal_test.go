@@ -0,0 +1,183 @@
+package bank
+
+import (
+	"errors"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+func openJournal(t *testing.T, dir string, options JournalOptions) *JournaledAccount {
+	t.Helper()
+	journal, err := OpenJournaledAccount(dir, options)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return journal
+}
+
+// slowSync mensimulasikan disk yang fsync-nya lambat. Selama flusher menunggu fsync, AddBalance
+// lain sudah mengantri sehingga batch berikutnya pasti berisi lebih dari satu record
+func slowSync(file *os.File) error {
+	time.Sleep(2 * time.Millisecond)
+	return file.Sync()
+}
+
+// TestJournalGroupCommit memastikan penulisan concurrent digabung ke fsync yang lebih sedikit
+// dan saldo pulih setelah journal dibuka ulang
+func TestJournalGroupCommit(t *testing.T) {
+	dir := t.TempDir()
+	journal := openJournal(t, dir, JournalOptions{syncFile: slowSync})
+
+	group := sync.WaitGroup{}
+	for i := 0; i < 50; i++ {
+		group.Add(1)
+		go func() {
+			defer group.Done()
+			for j := 0; j < 20; j++ {
+				if err := journal.AddBalance(1); err != nil {
+					t.Error(err)
+				}
+			}
+		}()
+	}
+	group.Wait()
+
+	stats := journal.Stats()
+	if journal.GetBalance() != 1000 || stats.Records != 1000 || stats.Syncs >= stats.Records {
+		t.Fatalf("balance = %d, stats = %+v", journal.GetBalance(), stats)
+	}
+	t.Logf("%d record dengan %d fsync", stats.Records, stats.Syncs)
+	if err := journal.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := journal.AddBalance(1); !errors.Is(err, ErrJournalClosed) {
+		t.Fatalf("AddBalance setelah Close = %v", err)
+	}
+
+	reopened := openJournal(t, dir, JournalOptions{})
+	defer reopened.Close()
+	if reopened.GetBalance() != 1000 || reopened.Stats().Recovered != 1000 {
+		t.Fatalf("balance = %d, stats = %+v", reopened.GetBalance(), reopened.Stats())
+	}
+}
+
+// writeLog membuat direktori journal baru yang log-nya berisi data
+func writeLog(t *testing.T, data []byte) string {
+	t.Helper()
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, journalLog), data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+// TestJournalCrash mensimulasikan crash dengan memotong log di offset acak dan merusak satu byte.
+// Saldo hasil pemulihan harus sama dengan jumlah record utuh sebelum titik kerusakan, dan journal
+// tetap dapat ditulis setelahnya
+func TestJournalCrash(t *testing.T) {
+	dir := t.TempDir()
+	journal := openJournal(t, dir, JournalOptions{})
+	group := sync.WaitGroup{}
+	for i := 0; i < 10; i++ {
+		group.Add(1)
+		go func(base int) {
+			defer group.Done()
+			for j := 1; j <= 10; j++ {
+				journal.AddBalance(base*100 + j)
+			}
+		}(i)
+	}
+	group.Wait()
+	journal.Close()
+
+	data, err := os.ReadFile(filepath.Join(dir, journalLog))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// prefix[k] adalah saldo setelah k record pertama sesuai urutan di log
+	prefix := []int{0}
+	for offset := 0; offset < len(data); offset += recordSize {
+		_, amount, ok := decodeRecord(data[offset : offset+recordSize])
+		if !ok {
+			t.Fatalf("record di offset %d rusak", offset)
+		}
+		prefix = append(prefix, prefix[len(prefix)-1]+int(amount))
+	}
+
+	random := rand.New(rand.NewSource(1))
+	for i := 0; i < 30; i++ {
+		offset := random.Intn(len(data) + 1)
+		complete := offset / recordSize
+		dir := writeLog(t, data[:offset])
+
+		recovered := openJournal(t, dir, JournalOptions{})
+		if recovered.GetBalance() != prefix[complete] {
+			t.Fatalf("offset %d: balance = %d, seharusnya %d", offset, recovered.GetBalance(), prefix[complete])
+		}
+		if err := recovered.AddBalance(7); err != nil {
+			t.Fatal(err)
+		}
+		recovered.Close()
+
+		// Sisa record yang terpotong harus sudah dibuang sehingga record baru ikut pulih
+		reopened := openJournal(t, dir, JournalOptions{})
+		if reopened.GetBalance() != prefix[complete]+7 {
+			t.Fatalf("offset %d: balance setelah tulis ulang = %d, seharusnya %d", offset, reopened.GetBalance(), prefix[complete]+7)
+		}
+		reopened.Close()
+	}
+
+	corrupted := append([]byte(nil), data...)
+	corrupted[42*recordSize+3] ^= 0xff
+	recovered := openJournal(t, writeLog(t, corrupted), JournalOptions{})
+	defer recovered.Close()
+	if recovered.GetBalance() != prefix[42] {
+		t.Fatalf("balance = %d, seharusnya %d", recovered.GetBalance(), prefix[42])
+	}
+}
+
+// TestJournalSnapshot memastikan log dikosongkan setelah snapshot, dan record lama yang masih
+// tersisa karena crash sebelum log dikosongkan tidak dihitung dua kali
+func TestJournalSnapshot(t *testing.T) {
+	dir := t.TempDir()
+	journal := openJournal(t, dir, JournalOptions{SnapshotEvery: 10})
+	for i := 1; i <= 25; i++ {
+		journal.AddBalance(i)
+	}
+	stats := journal.Stats()
+	journal.Close()
+	info, err := os.Stat(filepath.Join(dir, journalLog))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.Snapshots != 2 || info.Size() != 5*recordSize {
+		t.Fatalf("stats = %+v, ukuran log = %d", stats, info.Size())
+	}
+	reopened := openJournal(t, dir, JournalOptions{})
+	if reopened.GetBalance() != 325 || reopened.Stats().Recovered != 5 {
+		t.Fatalf("balance = %d, stats = %+v", reopened.GetBalance(), reopened.Stats())
+	}
+	reopened.Close()
+
+	// Simpan log sebelum snapshot, lalu kembalikan setelah snapshot seolah truncate tidak sempat terjadi
+	old, err := os.ReadFile(filepath.Join(dir, journalLog))
+	if err != nil {
+		t.Fatal(err)
+	}
+	snapshotting := openJournal(t, dir, JournalOptions{SnapshotEvery: 1})
+	snapshotting.AddBalance(100)
+	snapshotting.Close()
+	if err := os.WriteFile(filepath.Join(dir, journalLog), old, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	recovered := openJournal(t, dir, JournalOptions{})
+	defer recovered.Close()
+	if recovered.GetBalance() != 425 || recovered.Stats().Recovered != 0 {
+		t.Fatalf("balance = %d, stats = %+v", recovered.GetBalance(), recovered.Stats())
+	}
+}
@@ -0,0 +1,270 @@
+package bank
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"text/tabwriter"
+	"time"
+)
+
+// SimulationConfig mengatur Simulate. Field bernilai 0 diganti dengan nilai default
+type SimulationConfig struct {
+	Accounts       int           // Jumlah rekening, default 10
+	Workers        int           // Jumlah goroutine yang mengirim operasi, default 8
+	Duration       time.Duration // Lama simulasi, default 1 detik
+	InitialBalance int           // Saldo awal setiap rekening, default 1000
+	MaxAmount      int           // Nominal maksimal setiap operasi, default 100
+	CheckInterval  time.Duration // Jeda antar pemeriksaan invariant, default 10 milidetik
+	Seed           int64         // Seed angka acak, setiap worker memakai Seed + index
+}
+
+func (config SimulationConfig) withDefaults() SimulationConfig {
+	if config.Accounts < 2 {
+		config.Accounts = 10
+	}
+	if config.Workers <= 0 {
+		config.Workers = 8
+	}
+	if config.Duration <= 0 {
+		config.Duration = time.Second
+	}
+	if config.InitialBalance <= 0 {
+		config.InitialBalance = 1000
+	}
+	if config.MaxAmount <= 0 {
+		config.MaxAmount = 100
+	}
+	if config.CheckInterval <= 0 {
+		config.CheckInterval = 10 * time.Millisecond
+	}
+	return config
+}
+
+// SimulationReport adalah hasil Simulate
+type SimulationReport struct {
+	Strategy     string
+	Operations   int64 // Semua operasi, termasuk yang ditolak karena saldo tidak cukup
+	Deposits     int64 // Setoran yang berhasil
+	Withdrawals  int64 // Penarikan yang berhasil
+	Transfers    int64 // Transfer yang berhasil
+	Rejected     int64 // Operasi yang ditolak dengan ErrInsufficientFunds
+	Elapsed      time.Duration
+	Throughput   float64 // Operasi per detik
+	P50, P90     time.Duration
+	P99, Max     time.Duration
+	Checks       int      // Jumlah snapshot yang diperiksa selama simulasi
+	Violations   []string // Pelanggaran invariant, kosong jika strategy benar
+	FinalBalance int      // Total saldo di akhir simulasi
+}
+
+// ErrInvariant dikembalikan Simulate jika ada invariant yang dilanggar
+var ErrInvariant = errors.New("bank: invariant dilanggar")
+
+// flow mencatat jumlah uang yang masuk dan keluar dari sistem. Nilai started ditambah sebelum
+// operasi dimulai dan completed setelah operasi berhasil, sehingga snapshot di antara keduanya
+// dapat diperiksa dengan batas bawah dan batas atas. Operasi yang ditolak mengurangi started lagi,
+// agar batasnya tidak terus melebar selama simulasi
+type flow struct {
+	started   atomic.Int64
+	completed atomic.Int64
+}
+
+// worker adalah state lokal satu goroutine, digabung setelah simulasi selesai
+type worker struct {
+	random      *rand.Rand
+	deltas      []int // Perubahan saldo per rekening dari operasi worker ini yang berhasil
+	latencies   []time.Duration
+	deposits    int64
+	withdrawals int64
+	transfers   int64
+	rejected    int64
+	err         error
+}
+
+// Simulate membuat config.Accounts rekening dengan newStrategy, lalu menjalankan config.Workers
+// goroutine yang mengirim setoran, penarikan, dan transfer acak selama config.Duration.
+// Selama simulasi, snapshot diperiksa agar total uang sesuai dengan uang yang masuk dan keluar
+// dan tidak ada saldo negatif. Di akhir, saldo setiap rekening dibandingkan dengan jumlah perubahan
+// yang berhasil sehingga update yang hilang terdeteksi
+func Simulate(ctx context.Context, config SimulationConfig, newStrategy NewStrategy) (SimulationReport, error) {
+	config = config.withDefaults()
+	initial := make([]int, config.Accounts)
+	for i := range initial {
+		initial[i] = config.InitialBalance
+	}
+	initialTotal := config.Accounts * config.InitialBalance
+	strategy := newStrategy(initial)
+	defer strategy.Close()
+
+	ctx, cancel := context.WithTimeout(ctx, config.Duration)
+	defer cancel()
+
+	var deposits, withdrawals flow
+	workers := make([]*worker, config.Workers)
+	group := sync.WaitGroup{}
+	start := time.Now()
+	for i := range workers {
+		workers[i] = &worker{random: rand.New(rand.NewSource(config.Seed + int64(i))), deltas: make([]int, config.Accounts)}
+		group.Add(1)
+		go func(worker *worker) {
+			defer group.Done()
+			worker.run(ctx, config, strategy, &deposits, &withdrawals)
+		}(workers[i])
+	}
+
+	var violations []string
+	checks := 0
+	check := func(balances []int, low, high int) {
+		checks++
+		total := 0
+		for i, balance := range balances {
+			total += balance
+			if balance < 0 {
+				violations = append(violations, fmt.Sprintf("saldo rekening %d negatif: %d", i, balance))
+			}
+		}
+		if total < low || total > high {
+			violations = append(violations, fmt.Sprintf("total %d di luar rentang %d sampai %d", total, low, high))
+		}
+	}
+
+	ticker := time.NewTicker(config.CheckInterval)
+	defer ticker.Stop()
+checking:
+	for {
+		select {
+		case <-ctx.Done():
+			break checking
+		case <-ticker.C:
+			// Operasi yang berjalan bersamaan dengan snapshot bisa tercatat atau belum, sehingga
+			// batas bawah memakai setoran yang sudah selesai sebelum snapshot dan penarikan yang sudah
+			// dimulai setelah snapshot, batas atas sebaliknya
+			depositsDone, withdrawalsDone := deposits.completed.Load(), withdrawals.completed.Load()
+			balances := strategy.Snapshot()
+			low := initialTotal + int(depositsDone-withdrawals.started.Load())
+			high := initialTotal + int(deposits.started.Load()-withdrawalsDone)
+			check(balances, low, high)
+		}
+	}
+	group.Wait()
+	elapsed := time.Since(start)
+
+	report := SimulationReport{Strategy: strategy.Name(), Elapsed: elapsed}
+	expected := append([]int(nil), initial...)
+	var latencies []time.Duration
+	for _, worker := range workers {
+		if worker.err != nil {
+			violations = append(violations, "operasi gagal: "+worker.err.Error())
+		}
+		for i, delta := range worker.deltas {
+			expected[i] += delta
+		}
+		latencies = append(latencies, worker.latencies...)
+		report.Deposits += worker.deposits
+		report.Withdrawals += worker.withdrawals
+		report.Transfers += worker.transfers
+		report.Rejected += worker.rejected
+	}
+
+	final := strategy.Snapshot()
+	total := int(deposits.completed.Load()) - int(withdrawals.completed.Load()) + initialTotal
+	check(final, total, total)
+	for i := range final {
+		report.FinalBalance += final[i]
+		if final[i] != expected[i] {
+			violations = append(violations, fmt.Sprintf("update hilang di rekening %d: saldo %d, seharusnya %d", i, final[i], expected[i]))
+		}
+	}
+
+	report.Operations = int64(len(latencies))
+	report.Throughput = float64(report.Operations) / elapsed.Seconds()
+	sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+	report.P50 = percentile(latencies, 0.50)
+	report.P90 = percentile(latencies, 0.90)
+	report.P99 = percentile(latencies, 0.99)
+	report.Max = percentile(latencies, 1)
+	report.Checks = checks
+	report.Violations = violations
+	if len(violations) > 0 {
+		return report, fmt.Errorf("%w: %s", ErrInvariant, strings.Join(violations, "; "))
+	}
+	return report, nil
+}
+
+// run mengirim operasi acak sampai ctx selesai
+func (worker *worker) run(ctx context.Context, config SimulationConfig, strategy Strategy, deposits, withdrawals *flow) {
+	for ctx.Err() == nil {
+		account := worker.random.Intn(config.Accounts)
+		amount := 1 + worker.random.Intn(config.MaxAmount)
+		operation := worker.random.Intn(4)
+
+		start := time.Now()
+		var err error
+		switch operation {
+		case 0:
+			deposits.started.Add(int64(amount))
+			if err = strategy.Deposit(account, amount); err == nil {
+				deposits.completed.Add(int64(amount))
+				worker.deltas[account] += amount
+				worker.deposits++
+			} else {
+				deposits.started.Add(-int64(amount))
+			}
+		case 1:
+			withdrawals.started.Add(int64(amount))
+			if err = strategy.Withdraw(account, amount); err == nil {
+				withdrawals.completed.Add(int64(amount))
+				worker.deltas[account] -= amount
+				worker.withdrawals++
+			} else {
+				withdrawals.started.Add(-int64(amount))
+			}
+		default:
+			to := (account + 1 + worker.random.Intn(config.Accounts-1)) % config.Accounts
+			if err = strategy.Transfer(account, to, amount); err == nil {
+				worker.deltas[account] -= amount
+				worker.deltas[to] += amount
+				worker.transfers++
+			}
+		}
+		worker.latencies = append(worker.latencies, time.Since(start))
+
+		if errors.Is(err, ErrInsufficientFunds) {
+			worker.rejected++
+		} else if err != nil {
+			worker.err = err
+			return
+		}
+	}
+}
+
+// percentile mengambil nilai pada persentil p dari latencies yang sudah terurut
+func percentile(latencies []time.Duration, p float64) time.Duration {
+	if len(latencies) == 0 {
+		return 0
+	}
+	index := int(p*float64(len(latencies))+0.5) - 1
+	return latencies[min(max(index, 0), len(latencies)-1)]
+}
+
+// Print menulis report sebagai tabel dua kolom
+func (report SimulationReport) Print(out io.Writer) {
+	writer := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(writer, "strategy\t%s\n", report.Strategy)
+	fmt.Fprintf(writer, "operations\t%d (setor %d, tarik %d, transfer %d, ditolak %d)\n",
+		report.Operations, report.Deposits, report.Withdrawals, report.Transfers, report.Rejected)
+	fmt.Fprintf(writer, "throughput\t%.0f op/detik\n", report.Throughput)
+	fmt.Fprintf(writer, "latency\tp50 %s, p90 %s, p99 %s, max %s\n", report.P50, report.P90, report.P99, report.Max)
+	fmt.Fprintf(writer, "checks\t%d snapshot, %d pelanggaran\n", report.Checks, len(report.Violations))
+	for _, violation := range report.Violations {
+		fmt.Fprintf(writer, "\t%s\n", violation)
+	}
+	writer.Flush()
+}
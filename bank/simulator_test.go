@@ -0,0 +1,109 @@
+package bank
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestSimulateStrategies menjalankan simulator untuk setiap Strategy dan memastikan tidak ada
+// invariant yang dilanggar
+func TestSimulateStrategies(t *testing.T) {
+	for name, newStrategy := range Strategies {
+		t.Run(name, func(t *testing.T) {
+			config := SimulationConfig{Accounts: 5, Workers: 8, Duration: 100 * time.Millisecond, CheckInterval: time.Millisecond}
+			report, err := Simulate(context.Background(), config, newStrategy)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if report.Strategy != name || report.Transfers == 0 || report.Checks == 0 || report.P50 > report.P99 || report.P99 > report.Max {
+				t.Fatalf("report = %+v", report)
+			}
+
+			var out strings.Builder
+			report.Print(&out)
+			t.Log("\n" + out.String())
+		})
+	}
+}
+
+// TestStrategySelfTransfer memastikan setiap Strategy menolak transfer ke rekening yang sama
+// tanpa deadlock dan tanpa mengubah saldo
+func TestStrategySelfTransfer(t *testing.T) {
+	for name, newStrategy := range Strategies {
+		t.Run(name, func(t *testing.T) {
+			strategy := newStrategy([]int{100, 100})
+			defer strategy.Close()
+			if err := strategy.Transfer(0, 0, 10); !errors.Is(err, ErrSameAccount) {
+				t.Fatalf("err = %v", err)
+			}
+			if balances := strategy.Snapshot(); balances[0] != 100 || balances[1] != 100 {
+				t.Fatalf("saldo = %v", balances)
+			}
+		})
+	}
+}
+
+// rejectingStrategy menolak semua penarikan
+type rejectingStrategy struct {
+	globalLockStrategy
+}
+
+func (strategy *rejectingStrategy) Withdraw(account, amount int) error {
+	return ErrInsufficientFunds
+}
+
+// TestWorkerRejectedFlow memastikan penarikan yang ditolak tidak tercatat sebagai uang keluar,
+// sehingga batas bawah pemeriksaan selama simulasi tidak terus turun
+func TestWorkerRejectedFlow(t *testing.T) {
+	strategy := &rejectingStrategy{globalLockStrategy{balances: []int{100, 100}}}
+	config := SimulationConfig{Accounts: 2}.withDefaults()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	var deposits, withdrawals flow
+	worker := &worker{random: rand.New(rand.NewSource(1)), deltas: make([]int, config.Accounts)}
+	worker.run(ctx, config, strategy, &deposits, &withdrawals)
+
+	if worker.rejected == 0 || withdrawals.started.Load() != 0 || withdrawals.completed.Load() != 0 {
+		t.Fatalf("rejected = %d, withdrawals = %d/%d", worker.rejected, withdrawals.started.Load(), withdrawals.completed.Load())
+	}
+	if deposits.started.Load() != deposits.completed.Load() {
+		t.Fatalf("deposits = %d/%d", deposits.started.Load(), deposits.completed.Load())
+	}
+}
+
+// lostUpdateStrategy membaca dan menulis saldo di dua critical section terpisah,
+// sehingga setoran yang berjalan bersamaan saling menimpa
+type lostUpdateStrategy struct {
+	globalLockStrategy
+}
+
+func (strategy *lostUpdateStrategy) Deposit(account, amount int) error {
+	strategy.mutex.Lock()
+	balance := strategy.balances[account]
+	strategy.mutex.Unlock()
+
+	runtime.Gosched()
+
+	strategy.mutex.Lock()
+	strategy.balances[account] = balance + amount
+	strategy.mutex.Unlock()
+	return nil
+}
+
+// TestSimulateDetectsLostUpdate memastikan Strategy yang salah terdeteksi
+func TestSimulateDetectsLostUpdate(t *testing.T) {
+	newStrategy := func(balances []int) Strategy {
+		return &lostUpdateStrategy{globalLockStrategy{balances: append([]int(nil), balances...)}}
+	}
+	config := SimulationConfig{Accounts: 2, Workers: 8, Duration: 50 * time.Millisecond}
+	report, err := Simulate(context.Background(), config, newStrategy)
+	if !errors.Is(err, ErrInvariant) || len(report.Violations) == 0 {
+		t.Fatalf("err = %v, report = %+v", err, report)
+	}
+}
@@ -0,0 +1,114 @@
+package bank
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"sync/atomic"
+	"time"
+)
+
+// nextLockID adalah penghitung untuk lockID
+var nextLockID atomic.Uint64
+
+// lockID memberi rekening nomor unik yang dipakai sebagai urutan penguncian global: selama semua
+// fungsi yang mengunci lebih dari satu rekening mengikuti urutan ini, tidak ada dua goroutine yang
+// saling menunggu lock milik yang lain. Nomor diambil saat pertama kali dibutuhkan, sehingga
+// zero value rekening tetap siap dipakai
+type lockID struct {
+	id atomic.Uint64
+}
+
+// LockOrder mengembalikan urutan penguncian rekening, unik untuk setiap rekening
+func (lock *lockID) LockOrder() uint64 {
+	if id := lock.id.Load(); id != 0 {
+		return id
+	}
+	lock.id.CompareAndSwap(0, nextLockID.Add(1))
+	return lock.id.Load()
+}
+
+// lockOrder mengembalikan values tanpa duplikat, terurut berdasarkan LockOrder.
+// Duplikat dibuang karena mengunci lock yang sama dua kali akan deadlock
+func lockOrder[T interface{ LockOrder() uint64 }](values []T) []T {
+	sorted := append([]T(nil), values...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].LockOrder() < sorted[j].LockOrder() })
+	unique := sorted[:0]
+	for i, value := range sorted {
+		if i == 0 || value.LockOrder() != sorted[i-1].LockOrder() {
+			unique = append(unique, value)
+		}
+	}
+	return unique
+}
+
+// SnapshotAccounts membaca saldo semua accounts pada satu titik waktu yang konsisten dengan
+// memegang read lock semuanya sekaligus. Lock diambil berurutan berdasarkan LockOrder seperti
+// MoveBalance, sehingga snapshot tidak bisa deadlock dengan transfer yang berjalan bersamaan.
+// Hasilnya sesuai urutan argumen
+func SnapshotAccounts(accounts ...*Account) []int {
+	locked := lockOrder(accounts)
+	for _, account := range locked {
+		account.RWMutex.RLock()
+	}
+	balances := make([]int, len(accounts))
+	for i, account := range accounts {
+		balances[i] = account.Balance
+	}
+	for _, account := range locked {
+		account.RWMutex.RUnlock()
+	}
+	return balances
+}
+
+// MoveBalance memindahkan amount dari from ke to secara atomik. Kedua write lock diambil
+// berurutan berdasarkan LockOrder, sehingga transfer berlawanan arah tidak bisa deadlock
+func MoveBalance(from, to *Account, amount int) {
+	if from == to {
+		return
+	}
+	locked := lockOrder([]*Account{from, to})
+	locked[0].RWMutex.Lock()
+	locked[1].RWMutex.Lock()
+	from.Balance -= amount
+	to.Balance += amount
+	locked[1].RWMutex.Unlock()
+	locked[0].RWMutex.Unlock()
+}
+
+// SnapshotUsers membaca saldo semua users pada satu titik waktu yang konsisten. UserBalance
+// hanya memiliki sync.Mutex, sehingga snapshot memakai lock eksklusif dengan urutan LockOrder
+// yang sama seperti TransferOrdered. Hasilnya sesuai urutan argumen
+func SnapshotUsers(users ...*UserBalance) []int {
+	locked := lockOrder(users)
+	for _, user := range locked {
+		user.Lock()
+	}
+	balances := make([]int, len(users))
+	for i, user := range users {
+		balances[i] = user.Balance
+	}
+	for _, user := range locked {
+		user.Unlock()
+	}
+	return balances
+}
+
+// TransferOrdered sama seperti Transfer, tetapi kedua rekening dikunci berurutan berdasarkan
+// LockOrder sebelum saldo diubah, sehingga dua TransferOrdered berlawanan arah tidak deadlock
+// dan SnapshotUsers selalu melihat transfer secara utuh
+func TransferOrdered(out io.Writer, user1 Locked, user2 Locked, amount int, work time.Duration) {
+	locked := lockOrder([]Locked{user1, user2})
+	for _, user := range locked {
+		user.Lock()
+		fmt.Fprintln(out, "Lock", user.Owner())
+	}
+	user1.Change(-amount)
+	user2.Change(amount)
+
+	time.Sleep(work)
+
+	for i := len(locked) - 1; i >= 0; i-- {
+		locked[i].Unlock()
+	}
+}
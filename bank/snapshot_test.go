@@ -0,0 +1,117 @@
+package bank
+
+import (
+	"io"
+	"math/rand"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+// sum menjumlahkan semua saldo
+func sum(balances []int) int {
+	total := 0
+	for _, balance := range balances {
+		total += balance
+	}
+	return total
+}
+
+// TestSnapshotAccounts menjalankan MoveBalance acak dari banyak goroutine sambil mengambil snapshot
+// berulang kali. Total setiap snapshot harus sama dengan total awal, karena transfer yang terlihat
+// setengah jalan akan mengubah total
+func TestSnapshotAccounts(t *testing.T) {
+	accounts := make([]*Account, 8)
+	for i := range accounts {
+		accounts[i] = &Account{Balance: 1000}
+	}
+
+	var stop atomic.Bool
+	group := sync.WaitGroup{}
+	for i := 0; i < 8; i++ {
+		group.Add(1)
+		go func(seed int64) {
+			defer group.Done()
+			random := rand.New(rand.NewSource(seed))
+			for !stop.Load() {
+				from, to := accounts[random.Intn(len(accounts))], accounts[random.Intn(len(accounts))]
+				MoveBalance(from, to, 1+random.Intn(100))
+			}
+		}(int64(i))
+	}
+
+	for i := 0; i < 1000; i++ {
+		// Urutan argumen sengaja dibalik dan berisi duplikat
+		balances := SnapshotAccounts(accounts[7], accounts[3], accounts[0], accounts[3], accounts[1],
+			accounts[2], accounts[4], accounts[5], accounts[6])
+		if total := sum(balances) - balances[3]; total != 8000 {
+			t.Fatalf("snapshot %d: total = %d, seharusnya 8000", i, total)
+		}
+		if balances[1] != balances[3] {
+			t.Fatalf("snapshot %d: rekening yang sama berbeda saldo: %d dan %d", i, balances[1], balances[3])
+		}
+	}
+	stop.Store(true)
+	group.Wait()
+
+	if total := sum(SnapshotAccounts(accounts...)); total != 8000 {
+		t.Fatalf("total akhir = %d", total)
+	}
+}
+
+// TestSnapshotUsers memastikan TransferOrdered berlawanan arah tidak deadlock dan SnapshotUsers
+// tidak pernah melihat transfer yang baru tercatat di salah satu sisinya
+func TestSnapshotUsers(t *testing.T) {
+	users := []*UserBalance{{Name: "Aidil", Balance: 1000000}, {Name: "Budi", Balance: 1000000}, {Name: "Citra", Balance: 1000000}}
+
+	group := sync.WaitGroup{}
+	for i := 0; i < 6; i++ {
+		group.Add(1)
+		go func(i int) {
+			defer group.Done()
+			from, to := users[i%3], users[(i+1+i/3)%3]
+			for j := 0; j < 500; j++ {
+				TransferOrdered(io.Discard, from, to, 1000, 0)
+			}
+		}(i)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		group.Wait()
+		close(done)
+	}()
+	for checking := true; checking; {
+		select {
+		case <-done:
+			checking = false
+		default:
+		}
+		if total := sum(SnapshotUsers(users...)); total != 3000000 {
+			t.Fatalf("total = %d, seharusnya 3000000", total)
+		}
+	}
+
+	// Setiap rekening mengirim dan menerima jumlah yang sama, sehingga saldo akhir kembali ke awal
+	for _, balance := range SnapshotUsers(users...) {
+		if balance != 1000000 {
+			t.Fatalf("saldo akhir = %v", SnapshotUsers(users...))
+		}
+	}
+}
+
+// valueUser adalah Locked yang diimplementasikan oleh tipe value, bukan pointer
+type valueUser struct {
+	*UserBalance
+}
+
+// TestTransferOrderedValueType memastikan TransferOrdered menerima Locked bertipe value
+func TestTransferOrderedValueType(t *testing.T) {
+	user1 := valueUser{&UserBalance{Name: "Aidil", Balance: 1000}}
+	user2 := valueUser{&UserBalance{Name: "Budi", Balance: 1000}}
+	TransferOrdered(io.Discard, user1, user2, 100, 0)
+	TransferOrdered(io.Discard, user2, user1, 300, 0)
+	if balances := SnapshotUsers(user1.UserBalance, user2.UserBalance); balances[0] != 1200 || balances[1] != 800 {
+		t.Fatalf("saldo = %v", balances)
+	}
+}
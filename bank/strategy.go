@@ -0,0 +1,304 @@
+package bank
+
+import (
+	"belajar-golang-goroutines/actor"
+	"context"
+	"errors"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// ErrInsufficientFunds dikembalikan Strategy ketika saldo tidak cukup untuk penarikan atau transfer
+var ErrInsufficientFunds = errors.New("bank: saldo tidak cukup")
+
+// ErrSameAccount dikembalikan Strategy ketika rekening asal dan tujuan transfer sama
+var ErrSameAccount = errors.New("bank: transfer ke rekening yang sama")
+
+// Strategy adalah cara mengamankan sekumpulan rekening yang diakses banyak goroutine.
+// Rekening diidentifikasi dengan index 0 sampai jumlah rekening - 1
+type Strategy interface {
+	Name() string
+	Deposit(account, amount int) error
+	Withdraw(account, amount int) error
+	Transfer(from, to, amount int) error // Mengembalikan ErrSameAccount jika from sama dengan to
+	// Snapshot mengembalikan saldo semua rekening pada satu titik waktu yang konsisten:
+	// tidak ada transfer yang baru tercatat di salah satu sisinya
+	Snapshot() []int
+	Close()
+}
+
+// NewStrategy membuat Strategy untuk rekening dengan saldo awal balances
+type NewStrategy func(balances []int) Strategy
+
+// Strategies berisi semua Strategy yang tersedia, berdasarkan nama
+var Strategies = map[string]NewStrategy{
+	"global":  NewGlobalLockStrategy,
+	"ordered": NewOrderedLockStrategy,
+	"backoff": NewBackoffStrategy,
+	"actor":   NewActorStrategy,
+}
+
+// globalLockStrategy mengunci semua rekening dengan satu mutex. Paling sederhana dan pasti benar,
+// tetapi tidak ada dua operasi yang bisa berjalan bersamaan
+type globalLockStrategy struct {
+	mutex    sync.Mutex
+	balances []int
+}
+
+// NewGlobalLockStrategy membuat Strategy dengan satu lock global
+func NewGlobalLockStrategy(balances []int) Strategy {
+	return &globalLockStrategy{balances: append([]int(nil), balances...)}
+}
+
+func (strategy *globalLockStrategy) Name() string { return "global" }
+
+func (strategy *globalLockStrategy) Deposit(account, amount int) error {
+	strategy.mutex.Lock()
+	defer strategy.mutex.Unlock()
+	strategy.balances[account] += amount
+	return nil
+}
+
+func (strategy *globalLockStrategy) Withdraw(account, amount int) error {
+	strategy.mutex.Lock()
+	defer strategy.mutex.Unlock()
+	if strategy.balances[account] < amount {
+		return ErrInsufficientFunds
+	}
+	strategy.balances[account] -= amount
+	return nil
+}
+
+func (strategy *globalLockStrategy) Transfer(from, to, amount int) error {
+	if from == to {
+		return ErrSameAccount
+	}
+	strategy.mutex.Lock()
+	defer strategy.mutex.Unlock()
+	if strategy.balances[from] < amount {
+		return ErrInsufficientFunds
+	}
+	strategy.balances[from] -= amount
+	strategy.balances[to] += amount
+	return nil
+}
+
+func (strategy *globalLockStrategy) Snapshot() []int {
+	strategy.mutex.Lock()
+	defer strategy.mutex.Unlock()
+	return append([]int(nil), strategy.balances...)
+}
+
+func (strategy *globalLockStrategy) Close() {}
+
+// orderedLockStrategy memberi setiap rekening UserBalance dengan mutex sendiri. Transfer selalu
+// mengunci rekening dengan index lebih kecil terlebih dahulu, sehingga deadlock seperti pada
+// Transfer tidak mungkin terjadi
+type orderedLockStrategy struct {
+	accounts []*UserBalance
+}
+
+// NewOrderedLockStrategy membuat Strategy dengan lock per rekening yang dikunci berurutan
+func NewOrderedLockStrategy(balances []int) Strategy {
+	strategy := &orderedLockStrategy{accounts: make([]*UserBalance, len(balances))}
+	for i, balance := range balances {
+		strategy.accounts[i] = &UserBalance{Balance: balance}
+	}
+	return strategy
+}
+
+func (strategy *orderedLockStrategy) Name() string { return "ordered" }
+
+func (strategy *orderedLockStrategy) Deposit(account, amount int) error {
+	user := strategy.accounts[account]
+	user.Lock()
+	defer user.Unlock()
+	user.Change(amount)
+	return nil
+}
+
+func (strategy *orderedLockStrategy) Withdraw(account, amount int) error {
+	user := strategy.accounts[account]
+	user.Lock()
+	defer user.Unlock()
+	if user.Balance < amount {
+		return ErrInsufficientFunds
+	}
+	user.Change(-amount)
+	return nil
+}
+
+func (strategy *orderedLockStrategy) Transfer(from, to, amount int) error {
+	if from == to {
+		return ErrSameAccount
+	}
+	first, second := strategy.accounts[min(from, to)], strategy.accounts[max(from, to)]
+	first.Lock()
+	defer first.Unlock()
+	second.Lock()
+	defer second.Unlock()
+
+	if strategy.accounts[from].Balance < amount {
+		return ErrInsufficientFunds
+	}
+	strategy.accounts[from].Change(-amount)
+	strategy.accounts[to].Change(amount)
+	return nil
+}
+
+// Snapshot mengunci semua rekening dengan urutan yang sama seperti Transfer
+func (strategy *orderedLockStrategy) Snapshot() []int {
+	balances := make([]int, len(strategy.accounts))
+	for i, user := range strategy.accounts {
+		user.Lock()
+		balances[i] = user.Balance
+	}
+	for _, user := range strategy.accounts {
+		user.Unlock()
+	}
+	return balances
+}
+
+func (strategy *orderedLockStrategy) Close() {}
+
+// backoffStrategy memberi setiap rekening TimedUserBalance. Transfer mengunci rekening asal lalu
+// mencoba rekening tujuan dengan batas waktu, dan jika gagal melepas keduanya lalu menunggu acak
+// seperti TransferWithBackoff. Urutan penguncian bebas, deadlock dicegah oleh timeout
+type backoffStrategy struct {
+	accounts []*TimedUserBalance
+	timeout  time.Duration
+}
+
+// NewBackoffStrategy membuat Strategy dengan try-lock dan backoff acak
+func NewBackoffStrategy(balances []int) Strategy {
+	strategy := &backoffStrategy{accounts: make([]*TimedUserBalance, len(balances)), timeout: 100 * time.Microsecond}
+	for i, balance := range balances {
+		strategy.accounts[i] = &TimedUserBalance{Balance: balance}
+	}
+	return strategy
+}
+
+func (strategy *backoffStrategy) Name() string { return "backoff" }
+
+func (strategy *backoffStrategy) Deposit(account, amount int) error {
+	user := strategy.accounts[account]
+	user.Lock()
+	defer user.Unlock()
+	user.Change(amount)
+	return nil
+}
+
+func (strategy *backoffStrategy) Withdraw(account, amount int) error {
+	user := strategy.accounts[account]
+	user.Lock()
+	defer user.Unlock()
+	if user.Balance < amount {
+		return ErrInsufficientFunds
+	}
+	user.Change(-amount)
+	return nil
+}
+
+func (strategy *backoffStrategy) Transfer(from, to, amount int) error {
+	if from == to {
+		return ErrSameAccount
+	}
+	user1, user2 := strategy.accounts[from], strategy.accounts[to]
+	for {
+		user1.Lock()
+		if user2.TryLockFor(strategy.timeout) {
+			break
+		}
+		user1.Unlock()
+		time.Sleep(time.Duration(rand.Int63n(int64(strategy.timeout) + 1)))
+	}
+	defer user1.Unlock()
+	defer user2.Unlock()
+
+	if user1.Balance < amount {
+		return ErrInsufficientFunds
+	}
+	user1.Change(-amount)
+	user2.Change(amount)
+	return nil
+}
+
+// Snapshot mengunci semua rekening berurutan. Transfer yang memegang rekening berikutnya
+// akan timeout dan melepas lock-nya, sehingga Snapshot tidak bisa deadlock dengan Transfer
+func (strategy *backoffStrategy) Snapshot() []int {
+	balances := make([]int, len(strategy.accounts))
+	for i, user := range strategy.accounts {
+		user.Lock()
+		balances[i] = user.Balance
+	}
+	for _, user := range strategy.accounts {
+		user.Unlock()
+	}
+	return balances
+}
+
+func (strategy *backoffStrategy) Close() {}
+
+// actorStrategy menjadikan setiap rekening actor.Account dan transfer memakai protokol dua fase
+// actor.Transfer. Saldo setiap actor hanya konsisten untuk dirinya sendiri, sehingga Snapshot
+// menghentikan sementara operasi baru lewat gate dan menunggu operasi yang sedang berjalan selesai
+type actorStrategy struct {
+	supervisor *actor.Supervisor
+	accounts   []*actor.Account
+	gate       sync.RWMutex // Operasi memegang read lock, Snapshot memegang write lock
+}
+
+// NewActorStrategy membuat Strategy berbasis actor tanpa lock pada rekening
+func NewActorStrategy(balances []int) Strategy {
+	strategy := &actorStrategy{supervisor: actor.NewSupervisor(0), accounts: make([]*actor.Account, len(balances))}
+	for i, balance := range balances {
+		strategy.accounts[i] = strategy.supervisor.Spawn("", balance)
+	}
+	return strategy
+}
+
+func (strategy *actorStrategy) Name() string { return "actor" }
+
+// actorError mengubah error milik package actor menjadi error bank
+func actorError(err error) error {
+	if errors.Is(err, actor.ErrInsufficientFunds) {
+		return ErrInsufficientFunds
+	}
+	return err
+}
+
+func (strategy *actorStrategy) Deposit(account, amount int) error {
+	strategy.gate.RLock()
+	defer strategy.gate.RUnlock()
+	return actorError(strategy.accounts[account].Deposit(context.Background(), amount))
+}
+
+func (strategy *actorStrategy) Withdraw(account, amount int) error {
+	strategy.gate.RLock()
+	defer strategy.gate.RUnlock()
+	return actorError(strategy.accounts[account].Withdraw(context.Background(), amount))
+}
+
+func (strategy *actorStrategy) Transfer(from, to, amount int) error {
+	if from == to {
+		return ErrSameAccount
+	}
+	strategy.gate.RLock()
+	defer strategy.gate.RUnlock()
+	return actorError(actor.Transfer(context.Background(), strategy.accounts[from], strategy.accounts[to], amount))
+}
+
+func (strategy *actorStrategy) Snapshot() []int {
+	strategy.gate.Lock()
+	defer strategy.gate.Unlock()
+	balances := make([]int, len(strategy.accounts))
+	for i, account := range strategy.accounts {
+		balances[i], _ = account.Balance(context.Background())
+	}
+	return balances
+}
+
+func (strategy *actorStrategy) Close() {
+	strategy.supervisor.Stop()
+}
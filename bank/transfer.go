@@ -0,0 +1,135 @@
+package bank
+
+import (
+	"belajar-golang-goroutines/syncx"
+	"context"
+	"fmt"
+	"io"
+	"math/rand"
+	"runtime/trace"
+	"sync"
+	"time"
+)
+
+// Locked adalah rekening yang harus dikunci sebelum saldonya diubah.
+// Dipenuhi oleh UserBalance dan InstrumentedUserBalance
+type Locked interface {
+	sync.Locker
+	Owner() string     // Nama pemilik rekening
+	Change(amount int) // Mengubah saldo, pemanggil harus sudah memanggil Lock
+	LockOrder() uint64 // Urutan penguncian global, dipakai TransferOrdered
+}
+
+// UserBalance merepresentasikan pengguna dengan saldo.
+// Mutex di-embed sehingga UserBalance memenuhi sync.Locker
+type UserBalance struct {
+	sync.Mutex        // Harus dikunci sebelum mengakses atau memodifikasi Balance
+	Name       string // Nama pemilik rekening
+	Balance    int    // Jumlah saldo yang dimiliki
+	lockID            // Urutan penguncian untuk TransferOrdered dan SnapshotUsers
+}
+
+// Owner mengembalikan nama pemilik rekening
+func (user *UserBalance) Owner() string { return user.Name }
+
+// Change memodifikasi saldo pengguna, pemanggil harus sudah memanggil Lock.
+// amount positif untuk penambahan dan negatif untuk pengurangan
+func (user *UserBalance) Change(amount int) {
+	user.Balance = user.Balance + amount
+}
+
+// InstrumentedUserBalance adalah versi UserBalance yang memakai syncx.InstrumentedMutex,
+// sehingga pemegang lock dan goroutine yang menunggu dapat dilihat dari State
+type InstrumentedUserBalance struct {
+	syncx.InstrumentedMutex        // Name milik mutex diisi nama pemilik oleh NewInstrumentedUserBalance
+	Name                    string // Nama pemilik rekening
+	Balance                 int    // Jumlah saldo yang dimiliki
+	lockID                         // Urutan penguncian untuk TransferOrdered
+}
+
+// NewInstrumentedUserBalance membuat InstrumentedUserBalance yang mencatat goroutine pemegang lock
+func NewInstrumentedUserBalance(name string, balance int) *InstrumentedUserBalance {
+	user := &InstrumentedUserBalance{Name: name, Balance: balance}
+	user.InstrumentedMutex.Name = name
+	user.TrackHolder = true
+	return user
+}
+
+// Owner mengembalikan nama pemilik rekening
+func (user *InstrumentedUserBalance) Owner() string { return user.Name }
+
+// Change memodifikasi saldo pengguna, pemanggil harus sudah memanggil Lock
+func (user *InstrumentedUserBalance) Change(amount int) {
+	user.Balance = user.Balance + amount
+}
+
+// Transfer memindahkan amount dari user1 ke user2 dan mencatat setiap penguncian ke out.
+// work adalah simulasi proses setelah setiap penguncian.
+// PERINGATAN: penguncian tidak berurutan, sehingga dua Transfer berlawanan arah
+// yang berjalan bersamaan dapat deadlock
+func Transfer(out io.Writer, user1 Locked, user2 Locked, amount int, work time.Duration) {
+	TransferContext(context.Background(), out, user1, user2, amount, work)
+}
+
+// TransferContext sama seperti Transfer, tetapi setiap tahap ditandai sebagai region runtime/trace
+// ("transfer", "lock user1", dan "lock user2") di dalam task milik ctx, sehingga waktu menunggu
+// lock dapat dilihat di go tool trace atau diringkas dengan tracex.Summarize
+func TransferContext(ctx context.Context, out io.Writer, user1 Locked, user2 Locked, amount int, work time.Duration) {
+	defer trace.StartRegion(ctx, "transfer").End()
+
+	trace.WithRegion(ctx, "lock user1", user1.Lock)
+	fmt.Fprintln(out, "Lock user1", user1.Owner())
+	user1.Change(-amount)
+
+	time.Sleep(work)
+
+	trace.WithRegion(ctx, "lock user2", user2.Lock)
+	fmt.Fprintln(out, "Lock user2", user2.Owner())
+	user2.Change(amount)
+
+	time.Sleep(work)
+
+	user1.Unlock()
+	user2.Unlock()
+}
+
+// TimedUserBalance adalah versi UserBalance yang memakai syncx.TimedMutex,
+// sehingga penguncian dapat dibatasi waktu dan tetap kompatibel dengan sync.Locker
+type TimedUserBalance struct {
+	syncx.TimedMutex        // Mutex berbasis channel yang mendukung TryLockFor dan LockContext
+	Name             string // Nama pemilik rekening
+	Balance          int    // Jumlah saldo yang dimiliki
+}
+
+// Change memodifikasi saldo pengguna, pemanggil harus sudah memanggil Lock
+func (user *TimedUserBalance) Change(amount int) {
+	user.Balance = user.Balance + amount
+}
+
+// TransferWithBackoff memindahkan dana seperti Transfer, tetapi tidak menunggu rekening kedua
+// tanpa batas. Jika lock kedua tidak diperoleh dalam timeout, lock pertama dilepas dan transfer
+// diulang setelah delay acak sehingga deadlock tidak terjadi.
+// Mengembalikan jumlah percobaan sampai transfer berhasil
+func TransferWithBackoff(out io.Writer, user1 *TimedUserBalance, user2 *TimedUserBalance, amount int, work time.Duration, timeout time.Duration) int {
+	for attempt := 1; ; attempt++ {
+		user1.Lock()
+		fmt.Fprintln(out, "Lock user1", user1.Name, "percobaan", attempt)
+
+		time.Sleep(work)
+
+		if user2.TryLockFor(timeout) {
+			fmt.Fprintln(out, "Lock user2", user2.Name, "percobaan", attempt)
+			user1.Change(-amount)
+			user2.Change(amount)
+			user2.Unlock()
+			user1.Unlock()
+			return attempt
+		}
+
+		// Gagal mendapatkan lock kedua: lepaskan lock pertama lalu tunggu secara acak
+		// agar dua transfer yang berlawanan arah tidak terus bertabrakan
+		user1.Unlock()
+		fmt.Fprintln(out, "Backoff", user1.Name, "percobaan", attempt)
+		time.Sleep(time.Duration(rand.Int63n(int64(work+timeout) + 1)))
+	}
+}
@@ -0,0 +1,304 @@
+// Package cache berisi cache concurrent dengan batas jumlah entry (LRU), TTL per entry,
+// loader yang dideduplikasi dengan singleflight, dan statistik hit/miss. Berbeda dengan sync.Map
+// yang menyimpan nilai selamanya, entry lama dibuang otomatis
+package cache
+
+import (
+	"belajar-golang-goroutines/singleflight"
+	"belajar-golang-goroutines/timex"
+	"container/list"
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrNoLoader dikembalikan GetOrLoad jika Options.Loader tidak diisi
+var ErrNoLoader = errors.New("cache: loader tidak diatur")
+
+// EvictReason adalah alasan sebuah entry keluar dari cache
+type EvictReason int
+
+const (
+	// Evicted berarti entry dibuang karena cache penuh dan entry ini paling lama tidak dipakai
+	Evicted EvictReason = iota
+	// Expired berarti TTL entry sudah habis
+	Expired
+	// Removed berarti entry dihapus dengan Delete atau ditimpa dengan Set
+	Removed
+)
+
+// String mengembalikan nama alasan
+func (reason EvictReason) String() string {
+	switch reason {
+	case Evicted:
+		return "evicted"
+	case Expired:
+		return "expired"
+	case Removed:
+		return "removed"
+	}
+	return "unknown"
+}
+
+// Options mengatur perilaku Cache
+type Options[K comparable, V any] struct {
+	MaxEntries      int                                      // Jumlah entry maksimal, 0 berarti tanpa batas
+	TTL             time.Duration                            // TTL default untuk Set dan GetOrLoad, 0 berarti tidak pernah kedaluwarsa
+	CleanupInterval time.Duration                            // Periode janitor, default TTL/2. Tanpa TTL dan interval, janitor tidak dijalankan
+	Loader          func(key K) (V, error)                   // Dipanggil GetOrLoad ketika key tidak ada
+	OnEvict         func(key K, value V, reason EvictReason) // Dipanggil di luar lock setiap kali entry keluar
+	Clock           timex.Clock                              // Sumber waktu, default timex.Real
+}
+
+// Stats adalah statistik cache
+type Stats struct {
+	Hits        uint64 // Get yang menemukan entry
+	Misses      uint64 // Get yang tidak menemukan entry atau entry sudah kedaluwarsa
+	Loads       uint64 // Pemanggilan Loader, tidak termasuk pemanggil yang berbagi hasil
+	LoadErrors  uint64 // Pemanggilan Loader yang gagal
+	Evictions   uint64 // Entry yang dibuang karena cache penuh
+	Expirations uint64 // Entry yang dibuang karena TTL habis
+	Len         int    // Jumlah entry saat ini
+}
+
+// HitRate mengembalikan rasio hit terhadap semua Get, 0 jika belum ada Get
+func (stats Stats) HitRate() float64 {
+	if stats.Hits+stats.Misses == 0 {
+		return 0
+	}
+	return float64(stats.Hits) / float64(stats.Hits+stats.Misses)
+}
+
+// entry adalah satu elemen di daftar LRU
+type entry[K comparable, V any] struct {
+	key     K
+	value   V
+	expires time.Time // Zero value berarti tidak pernah kedaluwarsa
+}
+
+// eviction adalah entry yang keluar dan menunggu OnEvict dipanggil di luar lock
+type eviction[K comparable, V any] struct {
+	entry  *entry[K, V]
+	reason EvictReason
+}
+
+// Cache adalah cache LRU dengan TTL yang aman dipakai banyak goroutine
+type Cache[K comparable, V any] struct {
+	options Options[K, V]
+	loads   singleflight.Group[K, V]
+
+	mutex   sync.Mutex
+	items   map[K]*list.Element
+	order   *list.List // Depan adalah entry yang paling baru dipakai
+	stats   Stats
+	done    chan struct{}
+	stopped sync.WaitGroup
+	once    sync.Once
+}
+
+// New membuat Cache dan menjalankan janitor jika ada TTL atau CleanupInterval.
+// Panggil Close untuk menghentikan janitor
+func New[K comparable, V any](options Options[K, V]) *Cache[K, V] {
+	if options.Clock == nil {
+		options.Clock = timex.Real
+	}
+	if options.CleanupInterval <= 0 && options.TTL > 0 {
+		options.CleanupInterval = max(options.TTL/2, time.Millisecond)
+	}
+	cache := &Cache[K, V]{
+		options: options,
+		items:   make(map[K]*list.Element),
+		order:   list.New(),
+		done:    make(chan struct{}),
+	}
+	if options.CleanupInterval > 0 {
+		ticker := options.Clock.NewTicker(options.CleanupInterval)
+		cache.stopped.Add(1)
+		go cache.janitor(ticker)
+	}
+	return cache
+}
+
+// Get mengembalikan nilai key dan menandainya sebagai paling baru dipakai
+func (cache *Cache[K, V]) Get(key K) (V, bool) {
+	var evicted []eviction[K, V]
+	defer func() { cache.notify(evicted) }()
+
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	element, ok := cache.items[key]
+	if ok {
+		item := element.Value.(*entry[K, V])
+		if !cache.expired(item, cache.options.Clock.Now()) {
+			cache.stats.Hits++
+			cache.order.MoveToFront(element)
+			return item.value, true
+		}
+		evicted = append(evicted, cache.remove(element, Expired))
+	}
+	cache.stats.Misses++
+	var zero V
+	return zero, false
+}
+
+// Set menyimpan value dengan TTL default
+func (cache *Cache[K, V]) Set(key K, value V) {
+	cache.SetWithTTL(key, value, cache.options.TTL)
+}
+
+// SetWithTTL menyimpan value dengan TTL tertentu, 0 berarti tidak pernah kedaluwarsa.
+// Jika cache penuh, entry yang paling lama tidak dipakai dibuang
+func (cache *Cache[K, V]) SetWithTTL(key K, value V, ttl time.Duration) {
+	var evicted []eviction[K, V]
+	defer func() { cache.notify(evicted) }()
+
+	item := &entry[K, V]{key: key, value: value}
+	if ttl > 0 {
+		item.expires = cache.options.Clock.Now().Add(ttl)
+	}
+
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	if element, ok := cache.items[key]; ok {
+		evicted = append(evicted, cache.remove(element, Removed))
+	}
+	cache.items[key] = cache.order.PushFront(item)
+	for cache.options.MaxEntries > 0 && cache.order.Len() > cache.options.MaxEntries {
+		evicted = append(evicted, cache.remove(cache.order.Back(), Evicted))
+	}
+}
+
+// Delete menghapus key, mengembalikan false jika key tidak ada
+func (cache *Cache[K, V]) Delete(key K) bool {
+	var evicted []eviction[K, V]
+	defer func() { cache.notify(evicted) }()
+
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	element, ok := cache.items[key]
+	if !ok {
+		return false
+	}
+	evicted = append(evicted, cache.remove(element, Removed))
+	return true
+}
+
+// GetOrLoad mengembalikan nilai key, memanggil Loader jika key tidak ada. Banyak goroutine yang
+// meminta key yang sama secara bersamaan hanya memicu satu pemanggilan Loader.
+// ctx hanya membatasi waktu menunggu, Loader tetap berjalan sampai selesai untuk pemanggil lain
+func (cache *Cache[K, V]) GetOrLoad(ctx context.Context, key K) (V, error) {
+	if value, ok := cache.Get(key); ok {
+		return value, nil
+	}
+	if cache.options.Loader == nil {
+		var zero V
+		return zero, ErrNoLoader
+	}
+
+	result := cache.loads.DoChan(key, func() (V, error) {
+		cache.mutex.Lock()
+		cache.stats.Loads++
+		cache.mutex.Unlock()
+
+		value, err := cache.options.Loader(key)
+		if err != nil {
+			cache.mutex.Lock()
+			cache.stats.LoadErrors++
+			cache.mutex.Unlock()
+			return value, err
+		}
+		cache.Set(key, value)
+		return value, nil
+	})
+	select {
+	case loaded := <-result:
+		return loaded.Value, loaded.Err
+	case <-ctx.Done():
+		var zero V
+		return zero, ctx.Err()
+	}
+}
+
+// Len mengembalikan jumlah entry, termasuk yang sudah kedaluwarsa tetapi belum dibuang janitor
+func (cache *Cache[K, V]) Len() int {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	return cache.order.Len()
+}
+
+// Stats mengembalikan statistik cache
+func (cache *Cache[K, V]) Stats() Stats {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	stats := cache.stats
+	stats.Len = cache.order.Len()
+	return stats
+}
+
+// Close menghentikan janitor, aman dipanggil lebih dari sekali
+func (cache *Cache[K, V]) Close() {
+	cache.once.Do(func() {
+		close(cache.done)
+		cache.stopped.Wait()
+	})
+}
+
+// janitor membuang entry kedaluwarsa setiap kali ticker berbunyi
+func (cache *Cache[K, V]) janitor(ticker timex.Ticker) {
+	defer cache.stopped.Done()
+	defer ticker.Stop()
+	for {
+		select {
+		case <-cache.done:
+			return
+		case <-ticker.C():
+			// Memakai Now daripada waktu tick, sama seperti janitor KeyedRateLimiter
+			cache.removeExpired(cache.options.Clock.Now())
+		}
+	}
+}
+
+// removeExpired membuang semua entry yang TTL-nya sudah habis pada waktu now
+func (cache *Cache[K, V]) removeExpired(now time.Time) {
+	var evicted []eviction[K, V]
+	cache.mutex.Lock()
+	for element := cache.order.Back(); element != nil; {
+		previous := element.Prev()
+		if cache.expired(element.Value.(*entry[K, V]), now) {
+			evicted = append(evicted, cache.remove(element, Expired))
+		}
+		element = previous
+	}
+	cache.mutex.Unlock()
+	cache.notify(evicted)
+}
+
+// expired mengembalikan true jika TTL item sudah habis pada waktu now
+func (cache *Cache[K, V]) expired(item *entry[K, V], now time.Time) bool {
+	return !item.expires.IsZero() && !now.Before(item.expires)
+}
+
+// remove menghapus element dan mencatat statistiknya, pemanggil harus memegang mutex
+func (cache *Cache[K, V]) remove(element *list.Element, reason EvictReason) eviction[K, V] {
+	item := cache.order.Remove(element).(*entry[K, V])
+	delete(cache.items, item.key)
+	switch reason {
+	case Evicted:
+		cache.stats.Evictions++
+	case Expired:
+		cache.stats.Expirations++
+	}
+	return eviction[K, V]{entry: item, reason: reason}
+}
+
+// notify memanggil OnEvict untuk setiap entry yang keluar, pemanggil tidak boleh memegang mutex
+// agar OnEvict boleh memakai cache lagi
+func (cache *Cache[K, V]) notify(evicted []eviction[K, V]) {
+	if cache.options.OnEvict == nil {
+		return
+	}
+	for _, item := range evicted {
+		cache.options.OnEvict(item.entry.key, item.entry.value, item.reason)
+	}
+}
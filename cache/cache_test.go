@@ -0,0 +1,239 @@
+package cache
+
+import (
+	"belajar-golang-goroutines/timex"
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestLRU memastikan entry yang paling lama tidak dipakai dibuang ketika cache penuh
+func TestLRU(t *testing.T) {
+	var evicted []string
+	cache := New(Options[string, int]{
+		MaxEntries: 2,
+		OnEvict: func(key string, value int, reason EvictReason) {
+			evicted = append(evicted, key+":"+reason.String())
+		},
+	})
+	defer cache.Close()
+
+	cache.Set("a", 1)
+	cache.Set("b", 2)
+	cache.Get("a") // a menjadi paling baru dipakai
+	cache.Set("c", 3)
+
+	if _, ok := cache.Get("b"); ok {
+		t.Fatal("b seharusnya dibuang")
+	}
+	if value, ok := cache.Get("a"); !ok || value != 1 {
+		t.Fatalf("a = %d, %v", value, ok)
+	}
+	cache.Set("a", 10)
+	cache.Delete("c")
+
+	expected := []string{"b:evicted", "a:removed", "c:removed"}
+	if len(evicted) != len(expected) {
+		t.Fatalf("evicted = %v", evicted)
+	}
+	for i := range expected {
+		if evicted[i] != expected[i] {
+			t.Fatalf("evicted = %v, seharusnya %v", evicted, expected)
+		}
+	}
+
+	stats := cache.Stats()
+	if stats.Hits != 2 || stats.Misses != 1 || stats.Evictions != 1 || stats.Len != 1 {
+		t.Fatalf("stats = %+v", stats)
+	}
+}
+
+// TestTTL memastikan entry kedaluwarsa tidak dikembalikan Get dan dibuang oleh janitor
+func TestTTL(t *testing.T) {
+	clock := timex.NewFakeClock(time.Unix(0, 0))
+	expired := make(chan string, 10)
+	cache := New(Options[string, int]{
+		TTL:   time.Minute,
+		Clock: clock,
+		OnEvict: func(key string, value int, reason EvictReason) {
+			if reason == Expired {
+				expired <- key
+			}
+		},
+	})
+	defer cache.Close()
+
+	cache.Set("pendek", 1)
+	cache.SetWithTTL("panjang", 2, time.Hour)
+	cache.SetWithTTL("selamanya", 3, 0)
+
+	// Janitor berjalan setiap TTL/2, setelah satu menit entry pendek sudah kedaluwarsa
+	clock.Advance(30 * time.Second)
+	clock.Advance(30 * time.Second)
+	select {
+	case key := <-expired:
+		if key != "pendek" {
+			t.Fatalf("entry kedaluwarsa = %q", key)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("janitor seharusnya membuang entry kedaluwarsa")
+	}
+
+	if _, ok := cache.Get("pendek"); ok {
+		t.Fatal("entry kedaluwarsa tidak boleh dikembalikan")
+	}
+	if _, ok := cache.Get("panjang"); !ok {
+		t.Fatal("entry dengan TTL satu jam seharusnya masih ada")
+	}
+
+	// Get juga memeriksa TTL walaupun janitor belum berjalan
+	cache.SetWithTTL("sebentar", 4, time.Second)
+	clock.Advance(time.Second)
+	if _, ok := cache.Get("sebentar"); ok {
+		t.Fatal("entry kedaluwarsa tidak boleh dikembalikan sebelum janitor berjalan")
+	}
+	if stats := cache.Stats(); stats.Expirations != 2 {
+		t.Fatalf("expirations = %d", stats.Expirations)
+	}
+}
+
+// TestGetOrLoad menjalankan 100 goroutine yang meminta key yang sama dan memastikan
+// Loader hanya dipanggil sekali
+func TestGetOrLoad(t *testing.T) {
+	var loads atomic.Int32
+	release := make(chan struct{})
+	cache := New(Options[int, string]{
+		Loader: func(key int) (string, error) {
+			loads.Add(1)
+			<-release
+			return strconv.Itoa(key), nil
+		},
+	})
+	defer cache.Close()
+
+	group := sync.WaitGroup{}
+	for i := 0; i < 100; i++ {
+		group.Add(1)
+		go func() {
+			defer group.Done()
+			value, err := cache.GetOrLoad(context.Background(), 7)
+			if err != nil || value != "7" {
+				t.Errorf("value = %q, err = %v", value, err)
+			}
+		}()
+	}
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	group.Wait()
+
+	if loads.Load() != 1 {
+		t.Fatalf("Loader dipanggil %d kali", loads.Load())
+	}
+	if value, ok := cache.Get(7); !ok || value != "7" {
+		t.Fatalf("hasil Loader seharusnya disimpan, value = %q", value)
+	}
+	if stats := cache.Stats(); stats.Loads != 1 {
+		t.Fatalf("stats = %+v", stats)
+	}
+}
+
+// TestGetOrLoadError memastikan error Loader dikembalikan dan tidak disimpan
+func TestGetOrLoadError(t *testing.T) {
+	failure := errors.New("database mati")
+	cache := New(Options[string, int]{
+		Loader: func(key string) (int, error) { return 0, failure },
+	})
+	defer cache.Close()
+
+	if _, err := cache.GetOrLoad(context.Background(), "a"); !errors.Is(err, failure) {
+		t.Fatalf("err = %v", err)
+	}
+	if cache.Len() != 0 || cache.Stats().LoadErrors != 1 {
+		t.Fatalf("len = %d, stats = %+v", cache.Len(), cache.Stats())
+	}
+
+	empty := New(Options[string, int]{})
+	defer empty.Close()
+	if _, err := empty.GetOrLoad(context.Background(), "a"); !errors.Is(err, ErrNoLoader) {
+		t.Fatalf("err = %v", err)
+	}
+}
+
+// addToCache menyimpan value ke cache seperti AddToMap menyimpan ke sync.Map
+func addToCache(cache *Cache[int, int], value int, group *sync.WaitGroup) {
+	defer group.Done()
+	cache.Set(value, value)
+}
+
+// addToMap menyalin pola AddToMap di package utama
+func addToMap(data *sync.Map, value int, group *sync.WaitGroup) {
+	defer group.Done()
+	data.Store(value, value)
+}
+
+// BenchmarkConcurrentWrite membandingkan Cache dengan sync.Map memakai pola TestMap:
+// setiap iterasi menjalankan 100 goroutine yang masing-masing menyimpan satu nilai
+func BenchmarkConcurrentWrite(b *testing.B) {
+	b.Run("Cache", func(b *testing.B) {
+		cache := New(Options[int, int]{MaxEntries: 1000})
+		defer cache.Close()
+		for i := 0; i < b.N; i++ {
+			group := &sync.WaitGroup{}
+			for j := 0; j < 100; j++ {
+				group.Add(1)
+				go addToCache(cache, i*100+j, group)
+			}
+			group.Wait()
+		}
+	})
+
+	b.Run("SyncMap", func(b *testing.B) {
+		data := &sync.Map{}
+		for i := 0; i < b.N; i++ {
+			group := &sync.WaitGroup{}
+			for j := 0; j < 100; j++ {
+				group.Add(1)
+				go addToMap(data, i*100+j, group)
+			}
+			group.Wait()
+		}
+	})
+}
+
+// BenchmarkConcurrentRead membandingkan Get pada Cache dengan Load pada sync.Map.
+// Cache memakai satu mutex karena setiap Get mengubah urutan LRU, sedangkan sync.Map
+// dioptimalkan untuk key yang jarang berubah
+func BenchmarkConcurrentRead(b *testing.B) {
+	b.Run("Cache", func(b *testing.B) {
+		cache := New(Options[int, int]{})
+		defer cache.Close()
+		for i := 0; i < 100; i++ {
+			cache.Set(i, i)
+		}
+		b.RunParallel(func(pb *testing.PB) {
+			i := 0
+			for pb.Next() {
+				cache.Get(i % 100)
+				i++
+			}
+		})
+	})
+
+	b.Run("SyncMap", func(b *testing.B) {
+		data := &sync.Map{}
+		for i := 0; i < 100; i++ {
+			data.Store(i, i)
+		}
+		b.RunParallel(func(pb *testing.PB) {
+			i := 0
+			for pb.Next() {
+				data.Load(i % 100)
+				i++
+			}
+		})
+	})
+}
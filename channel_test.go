@@ -1,8 +1,15 @@
 package belajar_golang_goroutines
 
 import (
+	"belajar-golang-goroutines/chanutil"
+	"belajar-golang-goroutines/pubsub"
+	"belajar-golang-goroutines/queue"
+	"belajar-golang-goroutines/reqrep"
+	"context"
 	"fmt"
+	"os"
 	"strconv"
+	"sync"
 	"testing"
 	"time"
 )
@@ -29,12 +36,6 @@ func TestCreateChannel(t *testing.T) {
 	time.Sleep(5 * time.Second)  // Memberikan waktu untuk goroutine selesai
 }
 
-// GiveMeResponse adalah fungsi helper yang mengirim data ke channel setelah delay
-func GiveMeResponse(channel chan string) {
-	time.Sleep(2 * time.Second)
-	channel <- "Aidil Adam Baik Hati"
-}
-
 // TestChannelAsParameter mendemonstrasikan cara menggunakan channel sebagai parameter fungsi.
 // Fungsi ini menunjukkan pola umum dalam komunikasi antar goroutine menggunakan channel.
 func TestChannelAsParameter(t *testing.T) {
@@ -48,7 +49,7 @@ func TestChannelAsParameter(t *testing.T) {
 
 	// Menjalankan fungsi GiveMeResponse dalam goroutine terpisah
 	// dan mengirimkan channel sebagai parameter
-	go GiveMeResponse(channel)
+	go chanutil.GiveMeResponse(channel, "Aidil Adam Baik Hati", 2*time.Second)
 
 	// Menerima data dari channel (operasi blocking)
 	// Program akan menunggu sampai ada data yang dikirim
@@ -63,20 +64,6 @@ func TestChannelAsParameter(t *testing.T) {
 	time.Sleep(5 * time.Second)
 }
 
-// OnlyIn mendemonstrasikan channel yang hanya bisa menerima data (write-only)
-// chan<- menandakan channel hanya bisa digunakan untuk mengirim data
-func OnlyIn(channel chan<- string) {
-	time.Sleep(2 * time.Second)
-	channel <- "Aidil Adam Baik Hati"
-}
-
-// OnlyOut mendemonstrasikan channel yang hanya bisa membaca data (read-only)
-// <-chan menandakan channel hanya bisa digunakan untuk menerima data
-func OnlyOut(channel <-chan string) {
-	data := <-channel
-	fmt.Println(data)
-}
-
 // TestInOutChannel mendemonstrasikan penggunaan channel satu arah (unidirectional channel)
 // untuk memastikan keamanan tipe dan mencegah penggunaan channel yang tidak diinginkan
 func TestInOutChannel(t *testing.T) {
@@ -87,10 +74,10 @@ func TestInOutChannel(t *testing.T) {
 
 	// Menjalankan fungsi OnlyIn sebagai goroutine terpisah
 	// OnlyIn hanya dapat menulis ke channel (chan<-)
-	go OnlyIn(channel)
+	go chanutil.OnlyIn(channel, "Aidil Adam Baik Hati")
 	// Menjalankan fungsi OnlyOut sebagai goroutine terpisah
 	// OnlyOut hanya dapat membaca dari channel (<-chan)
-	go OnlyOut(channel)
+	go chanutil.OnlyOut(channel, os.Stdout)
 
 	// Memberikan waktu untuk goroutine menyelesaikan eksekusinya
 	// Note: Dalam produksi, lebih baik menggunakan sync.WaitGroup
@@ -169,8 +156,8 @@ func TestSelectChannel(t *testing.T) {
 
 	// Menjalankan dua goroutine yang akan mengirim data ke masing-masing channel
 	// setelah delay 2 detik
-	go GiveMeResponse(channel1)
-	go GiveMeResponse(channel2)
+	go chanutil.GiveMeResponse(channel1, "Aidil Adam Baik Hati", 2*time.Second)
+	go chanutil.GiveMeResponse(channel2, "Aidil Adam Baik Hati", 2*time.Second)
 
 	// Counter untuk melacak jumlah data yang telah diterima
 	counter := 0
@@ -208,8 +195,8 @@ func TestDefaultSelectChannel(t *testing.T) {
 	defer close(channel2)
 
 	// Menjalankan dua goroutine yang akan mengirim data ke masing-masing channel
-	go GiveMeResponse(channel1)
-	go GiveMeResponse(channel2)
+	go chanutil.GiveMeResponse(channel1, "Aidil Adam Baik Hati", 2*time.Second)
+	go chanutil.GiveMeResponse(channel2, "Aidil Adam Baik Hati", 2*time.Second)
 
 	// Counter untuk melacak jumlah data yang telah diterima
 	counter := 0
@@ -238,3 +225,148 @@ func TestDefaultSelectChannel(t *testing.T) {
 		}
 	}
 }
+
+// TestBroker menguji pubsub.Broker sebagai generalisasi GiveMeResponse:
+// satu pesan yang dipublish diterima oleh semua subscriber dari topic yang sama
+func TestBroker(t *testing.T) {
+	// Membuat broker dengan policy Block, publisher menunggu subscriber yang lambat
+	broker := pubsub.NewBroker[string](pubsub.Block)
+	// Menutup broker dan semua channel subscriber setelah fungsi selesai
+	defer broker.Close()
+
+	// Dua subscriber pada topic yang sama, masing-masing mendapat channel read-only
+	channel1, _ := broker.Subscribe("response", 1)
+	channel2, _ := broker.Subscribe("response", 1)
+
+	// Publisher mengirim satu pesan setelah delay seperti GiveMeResponse
+	go func() {
+		time.Sleep(2 * time.Second)
+		broker.Publish("response", "Aidil Adam Baik Hati")
+	}()
+
+	// Kedua subscriber menerima pesan yang sama
+	fmt.Println("Data dari Subscriber 1", <-channel1)
+	fmt.Println("Data dari Subscriber 2", <-channel2)
+}
+
+// TestRequestReply menguji reqrep sebagai versi eksplisit dari GiveMeResponse:
+// setiap request membawa payload dan menerima balasan yang sesuai dengan correlation ID-nya
+func TestRequestReply(t *testing.T) {
+	// Membuat pasangan requester dan responder tanpa buffer
+	requester, responder := reqrep.New[string, string](0)
+	// Menutup requester agar goroutine responder berhenti setelah fungsi selesai
+	defer requester.Close()
+
+	// Responder dengan 2 worker yang membalas setelah delay seperti GiveMeResponse
+	go responder.Serve(context.Background(), 2, func(ctx context.Context, name string) (string, error) {
+		time.Sleep(1 * time.Second)
+		return name + " Baik Hati", nil
+	})
+
+	// Dua request berjalan bersamaan dan masing-masing menerima balasannya sendiri
+	group := sync.WaitGroup{}
+	for _, name := range []string{"Aidil", "Adam"} {
+		group.Add(1)
+		go func() {
+			defer group.Done()
+			data, err := requester.RequestTimeout(name, 5*time.Second)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			fmt.Println("Balasan untuk", name, ":", data)
+		}()
+	}
+	group.Wait()
+}
+
+// TestBufferedQueue menjalankan TestBufferedChannel dengan queue.Queue berkapasitas 3.
+// Berbeda dengan channel, kapasitasnya dapat diubah, isinya dapat dikuras sekaligus,
+// dan statistiknya dapat dibaca
+func TestBufferedQueue(t *testing.T) {
+	buffer := queue.New[string](3)
+	ctx := context.Background()
+
+	// Mengirim 3 string, tidak blocking karena masih dalam kapasitas
+	buffer.Put(ctx, "Aidil")
+	buffer.Put(ctx, "Adam")
+	buffer.Put(ctx, "Baik")
+
+	// Antrian penuh, kapasitas dinaikkan saat runtime agar item keempat muat
+	buffer.SetCapacity(4)
+	buffer.Put(ctx, "Hati")
+
+	// Menguras semua item sekaligus, lalu menutup antrian seperti close(channel)
+	fmt.Println(buffer.DrainTo(nil, 0))
+	buffer.Close()
+
+	stats := buffer.Stats()
+	fmt.Println("High water mark", stats.HighWaterMark, "Puts", stats.Puts, "Takes", stats.Takes)
+	fmt.Println("Selesai")
+}
+
+// TestUnboundedChannel menjalankan TestBufferedChannel dengan chanutil.UnboundedChan:
+// pengirim tidak pernah blocking walaupun belum ada penerima, dan channel Out ditutup
+// setelah In ditutup dan semua data terbaca
+func TestUnboundedChannel(t *testing.T) {
+	// Kapasitas awal 3 seperti TestBufferedChannel, tetapi buffer akan membesar sendiri
+	channel := chanutil.NewUnboundedChan[string](chanutil.UnboundedOptions{
+		InitialCapacity: 3,
+		SoftLimit:       3,
+		OnSoftLimit: func(length int) {
+			fmt.Println("Peringatan: buffer berisi", length, "data")
+		},
+	})
+
+	// Mengirim 5 string tanpa blocking walaupun melebihi kapasitas awal
+	channel.In <- "Aidil"
+	channel.In <- "Adam"
+	channel.In <- "Baik"
+	channel.In <- "Hati"
+	channel.In <- "Sekali"
+	// Menutup In, Out akan ditutup setelah semua data dibaca
+	close(channel.In)
+
+	// Range loop berhenti ketika Out ditutup
+	for data := range channel.Out {
+		fmt.Println(data)
+	}
+	fmt.Println("Selesai")
+}
+
+// TestPrioritySelect adalah versi TestSelectChannel yang tidak memilih secara acak:
+// chanutil.PriorityMerge mengutamakan channel berprioritas tinggi, dan aging memastikan
+// channel berprioritas rendah tetap mendapat giliran
+func TestPrioritySelect(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Channel penting yang selalu berisi data
+	important := make(chan string)
+	go func() {
+		defer close(important)
+		for {
+			select {
+			case important <- "Penting":
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	// Channel biasa dengan 3 data
+	normal := make(chan string, 3)
+	normal <- "Biasa 1"
+	normal <- "Biasa 2"
+	normal <- "Biasa 3"
+	close(normal)
+
+	// Setiap kali data biasa dilewati 2 kali, prioritasnya naik 1
+	merged := chanutil.PriorityMerge(ctx, chanutil.PriorityOptions{Aging: 2},
+		chanutil.PrioritySource[string]{Channel: important, Priority: 2},
+		chanutil.PrioritySource[string]{Channel: normal, Priority: 1},
+	)
+	for i := 0; i < 12; i++ {
+		fmt.Println(<-merged)
+	}
+}
@@ -0,0 +1,180 @@
+package chanutil
+
+import (
+	"sync"
+)
+
+// BroadcastOptions mengatur perilaku Broadcaster
+type BroadcastOptions struct {
+	// Buffer adalah kapasitas channel setiap listener. Publish menunggu sampai semua listener
+	// punya ruang, sehingga listener yang lambat memperlambat pengirim
+	Buffer int
+	// Latest mengaktifkan mode watch: listener hanya menerima nilai terbaru, nilai lama yang belum
+	// dibaca diganti, dan listener yang baru bergabung langsung menerima nilai terakhir.
+	// Pada mode ini Publish tidak pernah menunggu dan Buffer diabaikan
+	Latest bool
+}
+
+// Broadcaster mengirim setiap nilai ke semua listener, berbeda dengan channel biasa
+// yang setiap nilainya hanya diterima satu penerima, mirip Broadcast pada sync.Cond
+type Broadcaster[T any] struct {
+	options BroadcastOptions
+
+	mutex     sync.Mutex
+	listeners map[*listener[T]]struct{}
+	latest    T
+	hasLatest bool
+	closed    bool
+
+	done      chan struct{} // Ditutup oleh Close sebelum mengambil mutex, membatalkan Publish yang sedang menunggu
+	closeOnce sync.Once
+}
+
+// listener adalah satu penerima Broadcaster
+type listener[T any] struct {
+	channel chan T
+	done    chan struct{} // Ditutup ketika listener berhenti, membatalkan Publish yang sedang menunggu
+	once    sync.Once
+}
+
+// NewBroadcaster membuat Broadcaster
+func NewBroadcaster[T any](options BroadcastOptions) *Broadcaster[T] {
+	if options.Latest || options.Buffer < 0 {
+		options.Buffer = 0
+	}
+	return &Broadcaster[T]{options: options, listeners: make(map[*listener[T]]struct{}), done: make(chan struct{})}
+}
+
+// Listen mendaftarkan listener baru dan mengembalikan channel penerimanya beserta fungsi untuk berhenti.
+// Listener hanya menerima nilai yang dikirim setelah Listen, kecuali nilai terakhir pada mode Latest.
+// Channel ditutup setelah fungsi berhenti dipanggil atau Broadcaster ditutup
+func (broadcaster *Broadcaster[T]) Listen() (<-chan T, func()) {
+	buffer := broadcaster.options.Buffer
+	if broadcaster.options.Latest {
+		buffer = 1
+	}
+	l := &listener[T]{channel: make(chan T, buffer), done: make(chan struct{})}
+
+	broadcaster.mutex.Lock()
+	defer broadcaster.mutex.Unlock()
+	if broadcaster.closed {
+		close(l.channel)
+		return l.channel, func() {}
+	}
+	if broadcaster.options.Latest && broadcaster.hasLatest {
+		l.channel <- broadcaster.latest
+	}
+	broadcaster.listeners[l] = struct{}{}
+
+	return l.channel, func() {
+		// done ditutup sebelum mengambil mutex agar Publish yang menunggu listener ini berhenti
+		l.once.Do(func() { close(l.done) })
+		broadcaster.mutex.Lock()
+		defer broadcaster.mutex.Unlock()
+		if _, ok := broadcaster.listeners[l]; ok {
+			delete(broadcaster.listeners, l)
+			close(l.channel)
+		}
+	}
+}
+
+// Publish mengirim value ke semua listener saat ini. Mengembalikan false jika Broadcaster sudah ditutup,
+// termasuk jika Close dipanggil ketika Publish masih menunggu listener yang lambat
+func (broadcaster *Broadcaster[T]) Publish(value T) bool {
+	broadcaster.mutex.Lock()
+	defer broadcaster.mutex.Unlock()
+	if broadcaster.closed {
+		return false
+	}
+	broadcaster.latest, broadcaster.hasLatest = value, true
+
+	for l := range broadcaster.listeners {
+		if broadcaster.options.Latest {
+			// Mengganti nilai lama yang belum dibaca, mutex menjamin hanya Publish ini yang mengirim
+			select {
+			case <-l.channel:
+			default:
+			}
+			l.channel <- value
+			continue
+		}
+		select {
+		case l.channel <- value:
+		case <-l.done:
+		case <-broadcaster.done:
+			return false
+		}
+	}
+	return true
+}
+
+// Latest mengembalikan nilai terakhir yang dikirim, dan false jika belum ada
+func (broadcaster *Broadcaster[T]) Latest() (T, bool) {
+	broadcaster.mutex.Lock()
+	defer broadcaster.mutex.Unlock()
+	return broadcaster.latest, broadcaster.hasLatest
+}
+
+// Len mengembalikan jumlah listener saat ini
+func (broadcaster *Broadcaster[T]) Len() int {
+	broadcaster.mutex.Lock()
+	defer broadcaster.mutex.Unlock()
+	return len(broadcaster.listeners)
+}
+
+// Close menutup channel semua listener. Publish berikutnya diabaikan, dan Publish yang sedang
+// menunggu listener dibatalkan
+func (broadcaster *Broadcaster[T]) Close() {
+	// done ditutup sebelum mengambil mutex, karena Publish yang menunggu memegang mutex tersebut
+	broadcaster.closeOnce.Do(func() { close(broadcaster.done) })
+	broadcaster.mutex.Lock()
+	defer broadcaster.mutex.Unlock()
+	if broadcaster.closed {
+		return
+	}
+	broadcaster.closed = true
+	for l := range broadcaster.listeners {
+		delete(broadcaster.listeners, l)
+		close(l.channel)
+	}
+}
+
+// Signal adalah sinyal sekali pakai yang membangunkan semua goroutine yang menunggu dengan
+// menutup channel, seperti cond.Broadcast tetapi bisa dipakai di dalam select.
+// Zero value siap dipakai
+type Signal struct {
+	once    sync.Once
+	mutex   sync.Mutex
+	channel chan struct{}
+}
+
+// init membuat channel secara lazy agar zero value Signal bisa dipakai
+func (signal *Signal) init() chan struct{} {
+	signal.mutex.Lock()
+	defer signal.mutex.Unlock()
+	if signal.channel == nil {
+		signal.channel = make(chan struct{})
+	}
+	return signal.channel
+}
+
+// Done mengembalikan channel yang ditutup ketika Fire dipanggil
+func (signal *Signal) Done() <-chan struct{} {
+	return signal.init()
+}
+
+// Fire membangunkan semua goroutine yang menunggu Done. Aman dipanggil lebih dari sekali
+func (signal *Signal) Fire() {
+	channel := signal.init()
+	signal.once.Do(func() { close(channel) })
+}
+
+// Fired mengembalikan true jika Fire sudah dipanggil
+func (signal *Signal) Fired() bool {
+	select {
+	case <-signal.Done():
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,142 @@
+package chanutil
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+// TestBroadcasterAllListeners memastikan setiap listener menerima setiap nilai sesuai urutan
+func TestBroadcasterAllListeners(t *testing.T) {
+	broadcaster := NewBroadcaster[int](BroadcastOptions{Buffer: 1})
+
+	group := sync.WaitGroup{}
+	for i := 0; i < 10; i++ {
+		channel, _ := broadcaster.Listen()
+		group.Add(1)
+		go func() {
+			defer group.Done()
+			expected := 0
+			for value := range channel {
+				if value != expected {
+					t.Errorf("value = %d, seharusnya %d", value, expected)
+				}
+				expected++
+			}
+			if expected != 100 {
+				t.Errorf("diterima %d nilai", expected)
+			}
+		}()
+	}
+
+	for i := 0; i < 100; i++ {
+		broadcaster.Publish(i)
+	}
+	broadcaster.Close()
+	group.Wait()
+
+	if broadcaster.Publish(100) {
+		t.Fatal("Publish setelah Close seharusnya false")
+	}
+}
+
+// TestBroadcasterCancel memastikan listener yang berhenti tidak membuat Publish menunggu selamanya
+func TestBroadcasterCancel(t *testing.T) {
+	broadcaster := NewBroadcaster[int](BroadcastOptions{})
+	channel, cancel := broadcaster.Listen()
+
+	published := make(chan struct{})
+	go func() {
+		broadcaster.Publish(1)
+		close(published)
+	}()
+
+	time.Sleep(10 * time.Millisecond)
+	cancel()
+	select {
+	case <-published:
+	case <-time.After(time.Second):
+		t.Fatal("Publish seharusnya berhenti setelah listener berhenti")
+	}
+	if _, ok := <-channel; ok {
+		t.Fatal("channel listener seharusnya ditutup")
+	}
+	if broadcaster.Len() != 0 {
+		t.Fatalf("len = %d", broadcaster.Len())
+	}
+	cancel()
+}
+
+// TestBroadcasterCloseWhilePublishing memastikan Close tidak menunggu selamanya ketika Publish
+// tertahan oleh listener yang tidak membaca dan tidak berhenti
+func TestBroadcasterCloseWhilePublishing(t *testing.T) {
+	broadcaster := NewBroadcaster[int](BroadcastOptions{})
+	channel, _ := broadcaster.Listen()
+
+	published := make(chan bool)
+	go func() { published <- broadcaster.Publish(1) }()
+	time.Sleep(10 * time.Millisecond)
+
+	closed := make(chan struct{})
+	go func() {
+		broadcaster.Close()
+		close(closed)
+	}()
+	select {
+	case <-closed:
+	case <-time.After(time.Second):
+		t.Fatal("Close seharusnya membatalkan Publish yang menunggu")
+	}
+	if <-published {
+		t.Fatal("Publish yang dibatalkan Close seharusnya mengembalikan false")
+	}
+	if _, ok := <-channel; ok {
+		t.Fatal("channel listener seharusnya ditutup")
+	}
+}
+
+// TestBroadcasterLatest memastikan mode Latest tidak pernah menunggu dan listener baru
+// langsung menerima nilai terakhir
+func TestBroadcasterLatest(t *testing.T) {
+	broadcaster := NewBroadcaster[string](BroadcastOptions{Latest: true})
+	slow, _ := broadcaster.Listen()
+
+	broadcaster.Publish("satu")
+	broadcaster.Publish("dua")
+	broadcaster.Publish("tiga")
+	if value := <-slow; value != "tiga" {
+		t.Fatalf("listener lambat menerima %q, seharusnya nilai terbaru", value)
+	}
+
+	late, _ := broadcaster.Listen()
+	if value := <-late; value != "tiga" {
+		t.Fatalf("listener baru menerima %q", value)
+	}
+	if value, ok := broadcaster.Latest(); !ok || value != "tiga" {
+		t.Fatalf("latest = %q, %v", value, ok)
+	}
+}
+
+// TestSignal memastikan Fire membangunkan semua goroutine yang menunggu, seperti cond.Broadcast
+func TestSignal(t *testing.T) {
+	var signal Signal
+	if signal.Fired() {
+		t.Fatal("signal belum di-Fire")
+	}
+
+	group := sync.WaitGroup{}
+	for i := 0; i < 10; i++ {
+		group.Add(1)
+		go func() {
+			defer group.Done()
+			<-signal.Done()
+		}()
+	}
+	signal.Fire()
+	signal.Fire()
+	group.Wait()
+
+	if !signal.Fired() {
+		t.Fatal("signal seharusnya sudah di-Fire")
+	}
+}
@@ -0,0 +1,26 @@
+package chanutil
+
+import (
+	"fmt"
+	"io"
+	"time"
+)
+
+// GiveMeResponse mengirim message ke channel setelah delay, mensimulasikan
+// goroutine yang butuh waktu sebelum memberi jawaban
+func GiveMeResponse(channel chan<- string, message string, delay time.Duration) {
+	time.Sleep(delay)
+	channel <- message
+}
+
+// OnlyIn mengirim message ke channel yang hanya bisa dipakai untuk mengirim data (chan<-)
+func OnlyIn(channel chan<- string, message string) {
+	channel <- message
+}
+
+// OnlyOut menerima satu data dari channel yang hanya bisa dipakai untuk menerima data (<-chan)
+// dan menuliskannya ke out
+func OnlyOut(channel <-chan string, out io.Writer) {
+	data := <-channel
+	fmt.Fprintln(out, data)
+}
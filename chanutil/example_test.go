@@ -0,0 +1,37 @@
+package chanutil_test
+
+import (
+	"belajar-golang-goroutines/chanutil"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// ExampleGiveMeResponse adalah versi TestChannelAsParameter
+func ExampleGiveMeResponse() {
+	channel := make(chan string)
+	defer close(channel)
+
+	go chanutil.GiveMeResponse(channel, "Aidil Adam Baik Hati", 10*time.Millisecond)
+
+	fmt.Println(<-channel)
+	// Output: Aidil Adam Baik Hati
+}
+
+// ExampleOnlyOut adalah versi TestInOutChannel: OnlyIn hanya bisa mengirim
+// dan OnlyOut hanya bisa menerima
+func ExampleOnlyOut() {
+	channel := make(chan string)
+	defer close(channel)
+
+	group := sync.WaitGroup{}
+	group.Add(1)
+	go chanutil.OnlyIn(channel, "Aidil Adam Baik Hati")
+	go func() {
+		defer group.Done()
+		chanutil.OnlyOut(channel, os.Stdout)
+	}()
+	group.Wait()
+	// Output: Aidil Adam Baik Hati
+}
@@ -0,0 +1,139 @@
+package chanutil
+
+import (
+	"context"
+	"reflect"
+)
+
+// PrioritySource adalah channel input untuk PriorityMerge beserta prioritasnya
+type PrioritySource[T any] struct {
+	Channel  <-chan T // Channel sumber data
+	Priority int      // Semakin besar semakin diutamakan
+}
+
+// PriorityOptions mengatur perilaku PriorityMerge
+type PriorityOptions struct {
+	// Aging mencegah starvation: setiap kali item yang menunggu dilewati sebanyak Aging kali,
+	// prioritas efektifnya naik 1. Nilai 0 berarti prioritas murni tanpa aging
+	Aging int
+}
+
+// pendingItem adalah satu item yang sudah diterima dari sumber tetapi belum dikirim ke output
+type pendingItem[T any] struct {
+	value   T
+	skipped int // Berapa kali item ini dilewati oleh item dari sumber lain
+}
+
+// PriorityMerge menggabungkan beberapa channel menjadi satu channel output yang mengutamakan
+// sumber dengan prioritas lebih tinggi, berbeda dengan select yang memilih secara acak.
+// Setiap sumber hanya punya satu item yang menunggu, dan dengan Aging > 0 item tersebut dijamin
+// terkirim walaupun sumber berprioritas tinggi terus-menerus berisi data.
+// Output ditutup ketika semua sumber ditutup dan kosong, atau ketika ctx selesai
+func PriorityMerge[T any](ctx context.Context, options PriorityOptions, sources ...PrioritySource[T]) <-chan T {
+	out := make(chan T)
+	go func() {
+		defer close(out)
+		pending := make([]*pendingItem[T], len(sources))
+		open := make([]bool, len(sources))
+		for i := range sources {
+			open[i] = true
+		}
+
+		for {
+			// Mengambil item dari setiap sumber yang belum punya item menunggu tanpa blocking
+			waiting := 0
+			for i, source := range sources {
+				if pending[i] == nil && open[i] {
+					select {
+					case value, ok := <-source.Channel:
+						if ok {
+							pending[i] = &pendingItem[T]{value: value}
+						} else {
+							open[i] = false
+						}
+					default:
+					}
+				}
+				if pending[i] != nil {
+					waiting++
+				}
+			}
+
+			if waiting == 0 {
+				// Tidak ada item sama sekali, tunggu salah satu sumber secara blocking
+				index, value, ok := receiveAny(ctx, sources, open)
+				if index < 0 {
+					return
+				}
+				if ok {
+					pending[index] = &pendingItem[T]{value: value}
+				} else {
+					open[index] = false
+				}
+				continue
+			}
+
+			chosen := choose(sources, pending, options.Aging)
+			select {
+			case out <- pending[chosen].value:
+			case <-ctx.Done():
+				return
+			}
+			pending[chosen] = nil
+			for _, item := range pending {
+				if item != nil {
+					item.skipped++
+				}
+			}
+		}
+	}()
+	return out
+}
+
+// choose memilih item menunggu dengan prioritas efektif terbesar. Jika sama, item yang lebih
+// sering dilewati didahulukan, lalu sumber dengan urutan lebih awal
+func choose[T any](sources []PrioritySource[T], pending []*pendingItem[T], aging int) int {
+	chosen := -1
+	var bestPriority, bestSkipped int
+	for i, item := range pending {
+		if item == nil {
+			continue
+		}
+		priority := sources[i].Priority
+		if aging > 0 {
+			priority += item.skipped / aging
+		}
+		if chosen < 0 || priority > bestPriority || (priority == bestPriority && item.skipped > bestSkipped) {
+			chosen, bestPriority, bestSkipped = i, priority, item.skipped
+		}
+	}
+	return chosen
+}
+
+// receiveAny menunggu secara blocking sampai salah satu sumber yang masih terbuka mengirim data
+// atau ditutup. Mengembalikan index -1 jika ctx selesai atau semua sumber sudah ditutup
+func receiveAny[T any](ctx context.Context, sources []PrioritySource[T], open []bool) (int, T, bool) {
+	var zero T
+	cases := []reflect.SelectCase{{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(ctx.Done())}}
+	indexes := []int{-1}
+	for i, source := range sources {
+		if open[i] {
+			cases = append(cases, reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(source.Channel)})
+			indexes = append(indexes, i)
+		}
+	}
+	if len(cases) == 1 {
+		return -1, zero, false
+	}
+
+	chosen, value, ok := reflect.Select(cases)
+	if chosen == 0 {
+		return -1, zero, false
+	}
+	if !ok {
+		return indexes[chosen], zero, false
+	}
+	// Bentuk dua nilai agar nil tidak panic ketika T adalah interface
+	typed, _ := value.Interface().(T)
+	return indexes[chosen], typed, true
+}
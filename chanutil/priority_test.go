@@ -0,0 +1,146 @@
+package chanutil
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// flood mengirim value terus-menerus ke channel sampai ctx selesai
+func flood(ctx context.Context, value string) <-chan string {
+	channel := make(chan string)
+	go func() {
+		defer close(channel)
+		for {
+			select {
+			case channel <- value:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return channel
+}
+
+// TestPriorityMergeOrder memastikan item berprioritas tinggi didahulukan ketika keduanya tersedia
+func TestPriorityMergeOrder(t *testing.T) {
+	high := make(chan int, 3)
+	low := make(chan int, 3)
+	for i := 0; i < 3; i++ {
+		high <- 10 + i
+		low <- i
+	}
+	close(high)
+	close(low)
+
+	merged := PriorityMerge(context.Background(), PriorityOptions{},
+		PrioritySource[int]{Channel: low, Priority: 1},
+		PrioritySource[int]{Channel: high, Priority: 2},
+	)
+	var result []int
+	for item := range merged {
+		result = append(result, item)
+	}
+	expected := []int{10, 11, 12, 0, 1, 2}
+	if len(result) != len(expected) {
+		t.Fatalf("hasil = %v", result)
+	}
+	for i := range expected {
+		if result[i] != expected[i] {
+			t.Fatalf("hasil = %v, seharusnya %v", result, expected)
+		}
+	}
+}
+
+// TestPriorityMergeAging memastikan sumber berprioritas rendah tetap mendapat giliran
+// walaupun sumber berprioritas tinggi dibanjiri data tanpa henti
+func TestPriorityMergeAging(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Item low selalu tersedia sehingga setiap jeda hanya ditentukan oleh aging
+	low := make(chan string, 5)
+	for i := 0; i < 5; i++ {
+		low <- "low"
+	}
+	close(low)
+
+	const aging = 4
+	merged := PriorityMerge(ctx, PriorityOptions{Aging: aging},
+		PrioritySource[string]{Channel: flood(ctx, "high"), Priority: 3},
+		PrioritySource[string]{Channel: low, Priority: 1},
+	)
+
+	// Item low menunggu paling lama (3-1)*aging giliran sebelum prioritas efektifnya menyamai high
+	bound := (3 - 1) * aging
+	lows, sinceLow := 0, 0
+	for lows < 5 {
+		item := <-merged
+		if item == "low" {
+			lows++
+			sinceLow = 0
+			continue
+		}
+		sinceLow++
+		if sinceLow > bound {
+			t.Fatalf("item low menunggu lebih dari %d giliran", bound)
+		}
+	}
+}
+
+// TestPriorityMergeStrictStarves menunjukkan bahwa tanpa aging sumber berprioritas rendah
+// bisa tidak pernah mendapat giliran
+func TestPriorityMergeStrictStarves(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	low := make(chan string, 1)
+	low <- "low"
+	merged := PriorityMerge(ctx, PriorityOptions{},
+		PrioritySource[string]{Channel: flood(ctx, "high"), Priority: 3},
+		PrioritySource[string]{Channel: low, Priority: 1},
+	)
+	// Memastikan item low sudah diterima dan menunggu sebelum dihitung
+	<-merged
+	<-merged
+	for i := 0; i < 1000; i++ {
+		if item := <-merged; item == "low" {
+			t.Fatal("tanpa aging item low seharusnya tidak pernah terpilih selama high tersedia")
+		}
+	}
+}
+
+// TestPriorityMergeCancel memastikan output ditutup ketika ctx dibatalkan
+func TestPriorityMergeCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	source := make(chan int)
+	merged := PriorityMerge(ctx, PriorityOptions{}, PrioritySource[int]{Channel: source})
+	cancel()
+	if _, ok := <-merged; ok {
+		t.Fatal("output seharusnya ditutup")
+	}
+}
+
+// TestPriorityMergeInterfaceNil memastikan nil dari sumber bertipe interface diteruskan tanpa panic
+// ketika diterima oleh receiveAny
+func TestPriorityMergeInterfaceNil(t *testing.T) {
+	source := make(chan error)
+	merged := PriorityMerge(context.Background(), PriorityOptions{}, PrioritySource[error]{Channel: source})
+	go func() {
+		// Menunggu sampai PriorityMerge tidak punya item dan memanggil receiveAny
+		time.Sleep(10 * time.Millisecond)
+		source <- nil
+		source <- errors.New("gagal")
+		close(source)
+	}()
+	if err, ok := <-merged; !ok || err != nil {
+		t.Fatalf("err = %v, ok = %v, seharusnya nil", err, ok)
+	}
+	if err := <-merged; err == nil || err.Error() != "gagal" {
+		t.Fatalf("err = %v", err)
+	}
+	if _, ok := <-merged; ok {
+		t.Fatal("output seharusnya ditutup")
+	}
+}
@@ -0,0 +1,133 @@
+// Package chanutil berisi helper dan adapter di atas channel Go
+package chanutil
+
+import (
+	"sync/atomic"
+)
+
+// UnboundedOptions mengatur perilaku UnboundedChan
+type UnboundedOptions struct {
+	InitialCapacity int           // Kapasitas awal buffer internal, default 16
+	SoftLimit       int           // Jumlah item yang dianggap terlalu banyak, 0 berarti tanpa batas
+	OnSoftLimit     func(len int) // Dipanggil sekali setiap kali jumlah item melewati SoftLimit
+}
+
+// UnboundedChan adalah channel tanpa batas kapasitas. Pengirim ke In tidak pernah menunggu consumer,
+// karena item yang belum dibaca dari Out disimpan di ring buffer yang membesar dan mengecil sesuai isi.
+// Menutup In akan menutup Out setelah semua item di buffer terbaca
+type UnboundedChan[T any] struct {
+	In  chan<- T // Hanya untuk mengirim, seperti parameter OnlyIn
+	Out <-chan T // Hanya untuk menerima, seperti parameter OnlyOut
+
+	length atomic.Int64
+}
+
+// NewUnboundedChan membuat UnboundedChan dan menjalankan goroutine pemindah item dari In ke Out
+func NewUnboundedChan[T any](options UnboundedOptions) *UnboundedChan[T] {
+	if options.InitialCapacity <= 0 {
+		options.InitialCapacity = 16
+	}
+	in := make(chan T)
+	out := make(chan T)
+	unbounded := &UnboundedChan[T]{In: in, Out: out}
+	go unbounded.run(in, out, options)
+	return unbounded
+}
+
+// Len mengembalikan jumlah item yang sedang berada di buffer internal
+func (unbounded *UnboundedChan[T]) Len() int {
+	return int(unbounded.length.Load())
+}
+
+func (unbounded *UnboundedChan[T]) run(in <-chan T, out chan<- T, options UnboundedOptions) {
+	defer close(out)
+	buffer := newRing[T](options.InitialCapacity)
+	warned := false
+
+	for {
+		if buffer.len() == 0 {
+			item, ok := <-in
+			if !ok {
+				return
+			}
+			buffer.push(item)
+		} else {
+			select {
+			case item, ok := <-in:
+				if !ok {
+					// In ditutup, kirim sisa item sebelum Out ditutup
+					for buffer.len() > 0 {
+						out <- buffer.peek()
+						buffer.pop()
+						unbounded.length.Store(int64(buffer.len()))
+					}
+					return
+				}
+				buffer.push(item)
+			case out <- buffer.peek():
+				buffer.pop()
+			}
+		}
+		unbounded.length.Store(int64(buffer.len()))
+
+		if options.SoftLimit > 0 {
+			if buffer.len() > options.SoftLimit && !warned {
+				warned = true
+				if options.OnSoftLimit != nil {
+					options.OnSoftLimit(buffer.len())
+				}
+			} else if buffer.len() <= options.SoftLimit {
+				warned = false
+			}
+		}
+	}
+}
+
+// ring adalah ring buffer yang ukurannya dikali dua ketika penuh dan dibagi dua
+// ketika isinya kurang dari seperempat, tetapi tidak pernah lebih kecil dari ukuran awal
+type ring[T any] struct {
+	items   []T
+	head    int
+	count   int
+	minimum int
+}
+
+func newRing[T any](capacity int) *ring[T] {
+	return &ring[T]{items: make([]T, capacity), minimum: capacity}
+}
+
+func (buffer *ring[T]) len() int { return buffer.count }
+
+func (buffer *ring[T]) push(item T) {
+	if buffer.count == len(buffer.items) {
+		buffer.resize(len(buffer.items) * 2)
+	}
+	buffer.items[(buffer.head+buffer.count)%len(buffer.items)] = item
+	buffer.count++
+}
+
+func (buffer *ring[T]) peek() T {
+	return buffer.items[buffer.head]
+}
+
+func (buffer *ring[T]) pop() {
+	var zero T
+	buffer.items[buffer.head] = zero
+	buffer.head = (buffer.head + 1) % len(buffer.items)
+	buffer.count--
+	if len(buffer.items) > buffer.minimum && buffer.count < len(buffer.items)/4 {
+		buffer.resize(max(len(buffer.items)/2, buffer.minimum))
+	}
+}
+
+// capacity mengembalikan ukuran ring buffer saat ini
+func (buffer *ring[T]) capacity() int { return len(buffer.items) }
+
+func (buffer *ring[T]) resize(capacity int) {
+	items := make([]T, capacity)
+	for i := 0; i < buffer.count; i++ {
+		items[i] = buffer.items[(buffer.head+i)%len(buffer.items)]
+	}
+	buffer.items = items
+	buffer.head = 0
+}
@@ -0,0 +1,97 @@
+package chanutil
+
+import (
+	"testing"
+	"time"
+)
+
+// TestUnboundedChan memastikan pengirim tidak pernah menunggu dan Out ditutup setelah buffer habis
+func TestUnboundedChan(t *testing.T) {
+	unbounded := NewUnboundedChan[int](UnboundedOptions{InitialCapacity: 2})
+
+	// Mengirim jauh lebih banyak item dari kapasitas awal tanpa ada consumer
+	done := make(chan struct{})
+	go func() {
+		for i := 0; i < 1000; i++ {
+			unbounded.In <- i
+		}
+		close(unbounded.In)
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("pengirim seharusnya tidak menunggu consumer")
+	}
+
+	expected := 0
+	for item := range unbounded.Out {
+		if item != expected {
+			t.Fatalf("item = %d, seharusnya %d", item, expected)
+		}
+		expected++
+	}
+	if expected != 1000 || unbounded.Len() != 0 {
+		t.Fatalf("diterima = %d, len = %d", expected, unbounded.Len())
+	}
+}
+
+// TestUnboundedSoftLimit memastikan callback dipanggil sekali setiap kali buffer melewati SoftLimit
+func TestUnboundedSoftLimit(t *testing.T) {
+	warnings := make(chan int, 10)
+	unbounded := NewUnboundedChan[int](UnboundedOptions{
+		SoftLimit:   5,
+		OnSoftLimit: func(length int) { warnings <- length },
+	})
+
+	for i := 0; i < 10; i++ {
+		unbounded.In <- i
+	}
+	for i := 0; i < 10; i++ {
+		<-unbounded.Out
+	}
+	for i := 0; i < 10; i++ {
+		unbounded.In <- i
+	}
+	close(unbounded.In)
+	for range unbounded.Out {
+	}
+
+	close(warnings)
+	count := 0
+	for length := range warnings {
+		if length != 6 {
+			t.Fatalf("callback dipanggil dengan len %d, seharusnya 6", length)
+		}
+		count++
+	}
+	if count != 2 {
+		t.Fatalf("callback dipanggil %d kali, seharusnya 2", count)
+	}
+}
+
+// TestRingResize memastikan ring buffer membesar dan mengecil tanpa mengubah urutan item
+func TestRingResize(t *testing.T) {
+	buffer := newRing[int](4)
+	for i := 0; i < 64; i++ {
+		buffer.push(i)
+	}
+	if buffer.capacity() != 64 {
+		t.Fatalf("capacity = %d", buffer.capacity())
+	}
+	for i := 0; i < 60; i++ {
+		if buffer.peek() != i {
+			t.Fatalf("peek = %d, seharusnya %d", buffer.peek(), i)
+		}
+		buffer.pop()
+	}
+	if buffer.capacity() >= 64 || buffer.capacity() < 4 {
+		t.Fatalf("capacity setelah mengecil = %d", buffer.capacity())
+	}
+	for i := 60; i < 64; i++ {
+		if buffer.peek() != i {
+			t.Fatalf("peek = %d, seharusnya %d", buffer.peek(), i)
+		}
+		buffer.pop()
+	}
+}
@@ -0,0 +1,216 @@
+// Command goroutines menjalankan demo concurrency dari package scenario dengan parameter
+// yang dapat diubah, sehingga hasilnya bisa dibandingkan tanpa menjalankan go test.
+//
+// Penggunaan:
+//
+//	goroutines <skenario> [flag]
+//	goroutines list
+//
+// Contoh:
+//
+//	goroutines race -goroutines 1000 -iterations 100
+//	goroutines deadlock -delay 1s -timeout 3s -v
+//	goroutines gomaxprocs -procs 20 -format json
+//	goroutines goroutine -goroutines 5000 -tui
+//	goroutines deadlock -procs 4 -trace deadlock.trace
+package main
+
+import (
+	"belajar-golang-goroutines/monitor"
+	"belajar-golang-goroutines/scenario"
+	"belajar-golang-goroutines/tracex"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"text/tabwriter"
+	"time"
+)
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	os.Exit(run(ctx, os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// run menjalankan CLI dan mengembalikan exit code, dipisah dari main agar dapat diuji
+func run(ctx context.Context, args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 || args[0] == "help" || args[0] == "-h" || args[0] == "-help" {
+		usage(stderr)
+		return 2
+	}
+	if args[0] == "list" {
+		list(stdout)
+		return 0
+	}
+
+	selected, ok := scenario.Lookup(args[0])
+	if !ok {
+		fmt.Fprintf(stderr, "skenario tidak dikenal: %s\n\n", args[0])
+		usage(stderr)
+		return 2
+	}
+
+	flags := flag.NewFlagSet(selected.Name, flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	config := scenario.Config{}
+	flags.IntVar(&config.Goroutines, "goroutines", selected.Defaults.Goroutines, "jumlah goroutine")
+	flags.IntVar(&config.Iterations, "iterations", selected.Defaults.Iterations, "jumlah perulangan di setiap goroutine")
+	flags.DurationVar(&config.Delay, "delay", selected.Defaults.Delay, "lama simulasi proses atau interval")
+	flags.DurationVar(&config.Timeout, "timeout", selected.Defaults.Timeout, "batas waktu skenario yang bisa macet")
+	flags.IntVar(&config.Procs, "procs", 0, "GOMAXPROCS selama skenario berjalan, 0 berarti tidak diubah")
+	format := flags.String("format", "text", "format output: text atau json")
+	verbose := flags.Bool("v", false, "tampilkan log kejadian ke stderr")
+	tui := flags.Bool("tui", false, "tampilkan dashboard goroutine, lock, dan channel ke stderr selama skenario berjalan")
+	refresh := flags.Duration("refresh", 200*time.Millisecond, "jeda antar frame dashboard -tui")
+	traceFile := flags.String("trace", "", "tulis runtime/trace ke file ini dan tampilkan ringkasannya ke stderr")
+	if err := flags.Parse(args[1:]); err != nil {
+		return 2
+	}
+	if *format != "text" && *format != "json" {
+		fmt.Fprintf(stderr, "format tidak dikenal: %s\n", *format)
+		return 2
+	}
+	if *verbose {
+		config.Log = stderr
+	}
+
+	execute := func(ctx context.Context) (scenario.Result, error) {
+		if *tui {
+			return runDashboard(ctx, selected, config, stderr, *refresh)
+		}
+		return selected.Run(ctx, config)
+	}
+	var result scenario.Result
+	var err error
+	if *traceFile != "" {
+		result, err = runTraced(ctx, selected.Name, *traceFile, stderr, execute)
+	} else {
+		result, err = execute(ctx)
+	}
+	if err != nil {
+		fmt.Fprintln(stderr, "gagal:", err)
+		return 1
+	}
+
+	if *format == "json" {
+		encoder := json.NewEncoder(stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(result); err != nil {
+			fmt.Fprintln(stderr, "gagal:", err)
+			return 1
+		}
+		return 0
+	}
+	writeText(stdout, result)
+	return 0
+}
+
+// runDashboard menjalankan skenario di goroutine lain sambil menggambar dashboard ke out
+// sampai skenario selesai. out adalah stderr agar hasil di stdout tetap utuh, misalnya JSON
+func runDashboard(ctx context.Context, selected scenario.Scenario, config scenario.Config, out io.Writer, refresh time.Duration) (scenario.Result, error) {
+	config.Monitor = monitor.New()
+	dashboard := &monitor.Dashboard{Monitor: config.Monitor, Out: out, Title: "goroutines " + selected.Name, Interval: refresh}
+
+	finished, cancel := context.WithCancel(ctx)
+	defer cancel()
+	done := make(chan struct{})
+	var result scenario.Result
+	var err error
+	go func() {
+		defer close(done)
+		defer cancel()
+		result, err = selected.Run(ctx, config)
+	}()
+	dashboard.Run(finished)
+
+	// Dashboard juga berhenti jika ctx dibatalkan, tunggu skenario benar-benar selesai
+	<-done
+	return result, err
+}
+
+// runTraced menjalankan execute di bawah runtime/trace, menyimpan trace ke path, lalu menulis
+// ringkasannya ke out. Ringkasan tidak ditulis ke stdout agar output JSON tidak berubah
+func runTraced(ctx context.Context, name, path string, out io.Writer, execute func(ctx context.Context) (scenario.Result, error)) (scenario.Result, error) {
+	file, err := os.Create(path)
+	if err != nil {
+		return scenario.Result{}, err
+	}
+	defer file.Close()
+
+	var result scenario.Result
+	var runErr error
+	if err := tracex.Capture(ctx, file, name, func(ctx context.Context) {
+		result, runErr = execute(ctx)
+	}); err != nil {
+		return scenario.Result{}, err
+	}
+	if runErr != nil {
+		return result, runErr
+	}
+
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return result, err
+	}
+	summary, err := tracex.Summarize(file)
+	if errors.Is(err, tracex.ErrUnsupportedVersion) {
+		// File trace tetap berguna untuk go tool trace walaupun tidak bisa diringkas
+		fmt.Fprintln(out, "ringkasan trace tidak tersedia:", err)
+		return result, nil
+	}
+	if err != nil {
+		return result, err
+	}
+	writeTrace(out, summary)
+	return result, nil
+}
+
+// writeTrace menulis ringkasan trace sebagai tabel, baris pertama adalah total seluruh goroutine
+func writeTrace(out io.Writer, summary tracex.Summary) {
+	writer := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(writer, "region\tcount\tduration\tgoroutines\tsync\tchannel\tsyscall\tother\n")
+	total := tracex.RegionSummary{Name: "(total)", Duration: summary.Duration, GoroutinesCreated: summary.GoroutinesCreated, Blocked: summary.Blocked}
+	for _, region := range append([]tracex.RegionSummary{total}, summary.Regions...) {
+		fmt.Fprintf(writer, "%s\t%d\t%s\t%d\t%s\t%s\t%s\t%s\n", region.Name, region.Count, region.Duration,
+			region.GoroutinesCreated, region.Sync, region.Channel, region.Syscall, region.Other)
+	}
+	writer.Flush()
+}
+
+// writeText menulis hasil sebagai tabel dua kolom
+func writeText(out io.Writer, result scenario.Result) {
+	writer := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(writer, "scenario\t%s\n", result.Scenario)
+	fmt.Fprintf(writer, "goroutines\t%d\n", result.Goroutines)
+	fmt.Fprintf(writer, "iterations\t%d\n", result.Iterations)
+	fmt.Fprintf(writer, "delay\t%s\n", result.Delay)
+	fmt.Fprintf(writer, "gomaxprocs\t%d\n", result.Procs)
+	fmt.Fprintf(writer, "elapsed\t%s\n", result.Elapsed)
+	for _, name := range result.MetricNames() {
+		fmt.Fprintf(writer, "%s\t%d\n", name, result.Metrics[name])
+	}
+	writer.Flush()
+}
+
+// list menulis nama dan deskripsi semua skenario
+func list(out io.Writer) {
+	writer := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	for _, item := range scenario.All() {
+		fmt.Fprintf(writer, "%s\t%s\n", item.Name, item.Description)
+	}
+	writer.Flush()
+}
+
+func usage(out io.Writer) {
+	fmt.Fprintln(out, "Penggunaan: goroutines <skenario> [flag]")
+	fmt.Fprintln(out, "           goroutines list")
+	fmt.Fprintln(out)
+	fmt.Fprintln(out, "Skenario:")
+	list(out)
+	fmt.Fprintln(out)
+	fmt.Fprintln(out, "Jalankan 'goroutines <skenario> -h' untuk melihat flag")
+}
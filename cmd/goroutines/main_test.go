@@ -0,0 +1,108 @@
+package main
+
+import (
+	"belajar-golang-goroutines/scenario"
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestRunJSON memastikan flag diteruskan ke skenario dan output JSON dapat dibaca script
+func TestRunJSON(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	code := run(context.Background(), []string{"mutex", "-goroutines", "5", "-iterations", "20", "-format", "json"}, &stdout, &stderr)
+	if code != 0 {
+		t.Fatalf("exit code = %d, stderr = %s", code, stderr.String())
+	}
+
+	var result scenario.Result
+	if err := json.Unmarshal(stdout.Bytes(), &result); err != nil {
+		t.Fatal(err)
+	}
+	if result.Scenario != "mutex" || result.Goroutines != 5 || result.Metrics["actual"] != 100 {
+		t.Fatalf("result = %+v", result)
+	}
+}
+
+// TestRunText memastikan output teks berisi metric skenario
+func TestRunText(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	code := run(context.Background(), []string{"once", "-goroutines", "10"}, &stdout, &stderr)
+	if code != 0 {
+		t.Fatalf("exit code = %d, stderr = %s", code, stderr.String())
+	}
+	if !strings.Contains(stdout.String(), "executed    1") {
+		t.Fatalf("stdout = %s", stdout.String())
+	}
+}
+
+// TestRunDashboard memastikan -tui menggambar dashboard ke stderr dan hasil JSON di stdout tetap valid
+func TestRunDashboard(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	args := []string{"goroutine", "-goroutines", "20", "-delay", "1ms", "-tui", "-refresh", "5ms", "-format", "json"}
+	if code := run(context.Background(), args, &stdout, &stderr); code != 0 {
+		t.Fatalf("exit code = %d, stderr = %s", code, stderr.String())
+	}
+
+	if !strings.Contains(stderr.String(), "goroutines goroutine") || !strings.Contains(stderr.String(), "results") {
+		t.Fatalf("stderr = %q", stderr.String())
+	}
+	// Dashboard tidak boleh bercampur dengan hasil JSON di stdout
+	var result scenario.Result
+	if err := json.Unmarshal(stdout.Bytes(), &result); err != nil {
+		t.Fatalf("stdout bukan JSON: %v\n%s", err, stdout.String())
+	}
+	if result.Metrics["received"] != 20 {
+		t.Fatalf("result = %+v", result)
+	}
+}
+
+// TestRunTrace memastikan -trace menulis file trace dan ringkasan region lock ke stderr
+func TestRunTrace(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "deadlock.trace")
+	var stdout, stderr bytes.Buffer
+	args := []string{"deadlock", "-delay", "10ms", "-timeout", "100ms", "-trace", path, "-format", "json"}
+	if code := run(context.Background(), args, &stdout, &stderr); code != 0 {
+		t.Fatalf("exit code = %d, stderr = %s", code, stderr.String())
+	}
+
+	if info, err := os.Stat(path); err != nil || info.Size() == 0 {
+		t.Fatalf("file trace: %v", err)
+	}
+	if strings.Contains(stderr.String(), "ringkasan trace tidak tersedia") {
+		t.Skip(stderr.String())
+	}
+	for _, expected := range []string{"(total)", "lock user1", "lock user2", "transfer"} {
+		if !strings.Contains(stderr.String(), expected) {
+			t.Fatalf("stderr tidak berisi %q:\n%s", expected, stderr.String())
+		}
+	}
+	var result scenario.Result
+	if err := json.Unmarshal(stdout.Bytes(), &result); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestRunInvalid memastikan skenario, flag, dan format yang salah menghasilkan exit code 2
+func TestRunInvalid(t *testing.T) {
+	for _, args := range [][]string{
+		nil,
+		{"tidak-ada"},
+		{"mutex", "-tidak-ada"},
+		{"mutex", "-format", "xml"},
+	} {
+		var stdout, stderr bytes.Buffer
+		if code := run(context.Background(), args, &stdout, &stderr); code != 2 {
+			t.Fatalf("args %v: exit code = %d", args, code)
+		}
+	}
+
+	var stdout, stderr bytes.Buffer
+	if code := run(context.Background(), []string{"list"}, &stdout, &stderr); code != 0 || !strings.Contains(stdout.String(), "deadlock") {
+		t.Fatalf("list: exit code = %d, stdout = %s", code, stdout.String())
+	}
+}
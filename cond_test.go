@@ -1,48 +1,29 @@
 package belajar_golang_goroutines
 
 import (
+	"belajar-golang-goroutines/chanutil"
+	"belajar-golang-goroutines/demo"
 	"fmt"
+	"os"
 	"sync"
 	"testing"
 	"time"
 )
 
-// Mutex untuk mengontrol akses ke resource yang dibagi
-var locker = sync.Mutex{}
-
-// Cond untuk mengimplementasikan mekanisme sinkronisasi menggunakan kondisi
-var cond = sync.NewCond(&locker)
-
-// WaitGroup untuk menunggu semua goroutine selesai
-var group = sync.WaitGroup{}
-
-// WaitCondition adalah fungsi yang akan dijalankan oleh goroutine
-// Parameter value digunakan untuk mengidentifikasi goroutine
-func WaitCondition(value int) {
-	// Menandakan goroutine telah selesai saat fungsi berakhir
-	defer group.Done()
-	
-	// Menambah counter WaitGroup
-	group.Add(1)
-
-	// Mengunci mutex sebelum mengakses conditional variable
-	cond.L.Lock()
-	
-	// Menunggu sinyal dari goroutine lain
-	cond.Wait()
-	
-	// Mencetak pesan setelah menerima sinyal
-	fmt.Println("Done", value)
-	
-	// Membuka kunci mutex setelah selesai
-	cond.L.Unlock()
-}
-
 // TestCond adalah fungsi test untuk mendemonstrasikan penggunaan sync.Cond
 func TestCond(t *testing.T) {
+	// Mutex untuk mengontrol akses ke resource yang dibagi
+	locker := sync.Mutex{}
+	// Cond untuk mengimplementasikan mekanisme sinkronisasi menggunakan kondisi
+	cond := sync.NewCond(&locker)
+	// WaitGroup untuk menunggu semua goroutine selesai
+	group := sync.WaitGroup{}
+
 	// Membuat 10 goroutine yang akan menunggu kondisi
 	for i := 0; i < 10; i++ {
-		go WaitCondition(i)
+		// Menambah counter WaitGroup sebelum goroutine dijalankan
+		group.Add(1)
+		go demo.WaitCondition(cond, &group, i, os.Stdout)
 	}
 
 //	Goroutine untuk mengirim sinyal satu per satu
@@ -65,3 +46,34 @@ func TestCond(t *testing.T) {
 	// Menunggu semua goroutine selesai
 	group.Wait()
 }
+
+// TestBroadcastChannel adalah versi TestCond berbasis channel: chanutil.Signal membangunkan
+// semua goroutine sekaligus seperti cond.Broadcast, dan chanutil.Broadcaster mengirim setiap
+// nilai ke semua listener, bukan hanya ke satu penerima seperti cond.Signal
+func TestBroadcastChannel(t *testing.T) {
+	var start chanutil.Signal
+	broadcaster := chanutil.NewBroadcaster[string](chanutil.BroadcastOptions{Buffer: 1})
+	waitGroup := sync.WaitGroup{}
+
+	for i := 0; i < 3; i++ {
+		// Listen dipanggil sebelum goroutine berjalan agar tidak ada nilai yang terlewat
+		messages, _ := broadcaster.Listen()
+		waitGroup.Add(1)
+		go func(value int) {
+			defer waitGroup.Done()
+			// Menunggu sinyal mulai, semua goroutine bangun bersamaan
+			<-start.Done()
+			for message := range messages {
+				fmt.Println("Listener", value, "menerima", message)
+			}
+		}(i)
+	}
+
+	time.Sleep(100 * time.Millisecond)
+	start.Fire()
+	broadcaster.Publish("Halo")
+	broadcaster.Publish("Dunia")
+	broadcaster.Close()
+
+	waitGroup.Wait()
+}
@@ -0,0 +1,256 @@
+// Package contention berisi helper untuk mengukur contention lock dan blocking goroutine
+// menggunakan mutex profile dan block profile milik runtime Go
+package contention
+
+import (
+	"bufio"
+	"bytes"
+	"runtime"
+	"runtime/pprof"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Site merepresentasikan satu lokasi pemanggilan yang mengalami contention
+type Site struct {
+	Blocker  string        // Fungsi sinkronisasi yang menahan goroutine, misalnya sync.(*Mutex).Lock
+	Function string        // Fungsi pemanggil pertama di luar package runtime dan sync
+	File     string        // File sumber dari fungsi pemanggil
+	Line     int           // Nomor baris dari fungsi pemanggil
+	Events   int64         // Jumlah kejadian contention
+	Delay    time.Duration // Total waktu tunggu
+}
+
+// Profile adalah ringkasan dari satu jenis profile (mutex atau block)
+type Profile struct {
+	Events int64         // Total kejadian contention
+	Delay  time.Duration // Total waktu tunggu
+	Sites  []Site        // Lokasi contention, diurutkan dari delay terbesar
+}
+
+// Locks mengembalikan bagian profile yang hanya berisi blocking pada Mutex dan RWMutex,
+// sehingga waktu tunggu WaitGroup, channel, atau select tidak ikut terhitung
+func (profile Profile) Locks() Profile {
+	return profile.Filter(func(site Site) bool {
+		return strings.Contains(site.Blocker, "Mutex).")
+	})
+}
+
+// Filter mengembalikan profile baru yang hanya berisi site yang lolos fungsi keep
+func (profile Profile) Filter(keep func(Site) bool) Profile {
+	result := Profile{}
+	for _, site := range profile.Sites {
+		if keep(site) {
+			result.Sites = append(result.Sites, site)
+			result.Events += site.Events
+			result.Delay += site.Delay
+		}
+	}
+	return result
+}
+
+// Top mengembalikan maksimal n site dengan delay terbesar
+func (profile Profile) Top(n int) []Site {
+	if n < len(profile.Sites) {
+		return profile.Sites[:n]
+	}
+	return profile.Sites
+}
+
+// Summary adalah hasil pengukuran dari satu workload
+type Summary struct {
+	Elapsed time.Duration // Lama workload berjalan
+	Mutex   Profile       // Delay yang dialami goroutine lain karena lock ditahan (dicatat saat Unlock)
+	Block   Profile       // Waktu goroutine terblokir pada primitive sinkronisasi
+}
+
+// Options mengatur sampling rate yang dipakai selama pengukuran
+type Options struct {
+	MutexFraction int // Nilai untuk runtime.SetMutexProfileFraction, 1 berarti semua kejadian
+	BlockRate     int // Nilai untuk runtime.SetBlockProfileRate dalam nanodetik, 1 berarti semua kejadian
+}
+
+// DefaultOptions merekam semua kejadian contention tanpa sampling
+var DefaultOptions = Options{MutexFraction: 1, BlockRate: 1}
+
+// measureLock memastikan hanya satu pengukuran yang berjalan dalam satu waktu,
+// karena profile runtime bersifat global untuk seluruh proses
+var measureLock sync.Mutex
+
+// Enable mengaktifkan mutex profile dan block profile, lalu mengembalikan fungsi
+// untuk mengembalikan setting sebelumnya
+func Enable(options Options) (restore func()) {
+	previousFraction := runtime.SetMutexProfileFraction(options.MutexFraction)
+	runtime.SetBlockProfileRate(options.BlockRate)
+	return func() {
+		runtime.SetMutexProfileFraction(previousFraction)
+		runtime.SetBlockProfileRate(0)
+	}
+}
+
+// Measure menjalankan workload dengan DefaultOptions dan mengembalikan ringkasan contention-nya
+func Measure(workload func()) Summary {
+	return MeasureWith(DefaultOptions, workload)
+}
+
+// MeasureWith menjalankan workload dengan profiling aktif dan mengembalikan selisih
+// profile sebelum dan sesudah workload dalam bentuk Summary
+func MeasureWith(options Options, workload func()) Summary {
+	measureLock.Lock()
+	defer measureLock.Unlock()
+
+	restore := Enable(options)
+	defer restore()
+
+	mutexBefore := readProfile("mutex")
+	blockBefore := readProfile("block")
+
+	start := time.Now()
+	workload()
+	elapsed := time.Since(start)
+
+	return Summary{
+		Elapsed: elapsed,
+		Mutex:   diff(mutexBefore, readProfile("mutex")),
+		Block:   diff(blockBefore, readProfile("block")),
+	}
+}
+
+// record adalah satu entri mentah dari profile dalam format teks (debug=1)
+type record struct {
+	cycles   int64
+	count    int64
+	blocker  string
+	function string
+	file     string
+	line     int
+}
+
+// rawProfile menyimpan entri profile berdasarkan stack sebagai key
+type rawProfile struct {
+	cyclesPerSecond float64
+	records         map[string]record
+}
+
+// readProfile mengambil profile dari pprof dalam format teks lalu mem-parsing-nya
+func readProfile(name string) rawProfile {
+	var buffer bytes.Buffer
+	pprof.Lookup(name).WriteTo(&buffer, 1)
+	return parse(&buffer)
+}
+
+// parse membaca format teks profile contention, contohnya:
+//
+//	--- mutex:
+//	cycles/second=2099996526
+//	sampling period=1
+//	92525506 2 @ 0x4df0d0 0x4df07a 0x483981
+//	#	0x4df0cf	sync.(*Mutex).Unlock+0x6f	/usr/local/go/src/sync/mutex.go:65
+//	#	0x4df079	main.main.func1+0x19		/tmp/p/main.go:7
+func parse(buffer *bytes.Buffer) rawProfile {
+	profile := rawProfile{records: map[string]record{}}
+	scanner := bufio.NewScanner(buffer)
+
+	var current *record
+	var key string
+	flush := func() {
+		if current != nil {
+			profile.records[key] = *current
+			current = nil
+		}
+	}
+
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case strings.HasPrefix(line, "cycles/second="):
+			profile.cyclesPerSecond, _ = strconv.ParseFloat(strings.TrimPrefix(line, "cycles/second="), 64)
+		case strings.Contains(line, " @ "):
+			flush()
+			fields := strings.Fields(line)
+			if len(fields) < 3 {
+				continue
+			}
+			cycles, _ := strconv.ParseInt(fields[0], 10, 64)
+			count, _ := strconv.ParseInt(fields[1], 10, 64)
+			key = line[strings.Index(line, "@"):]
+			current = &record{cycles: cycles, count: count}
+		case strings.HasPrefix(line, "#") && current != nil:
+			fields := strings.Fields(line)
+			if len(fields) < 4 {
+				continue
+			}
+			function := fields[2]
+			if index := strings.LastIndex(function, "+0x"); index >= 0 {
+				function = function[:index]
+			}
+			if current.blocker == "" {
+				current.blocker = function
+			}
+			if current.function == "" && !isSyncFrame(function) {
+				current.function = function
+				location := fields[3]
+				if index := strings.LastIndex(location, ":"); index >= 0 {
+					current.file = location[:index]
+					current.line, _ = strconv.Atoi(location[index+1:])
+				}
+			}
+		}
+	}
+	flush()
+	return profile
+}
+
+// isSyncFrame menandai frame milik runtime dan package sinkronisasi yang bukan call site sebenarnya
+func isSyncFrame(function string) bool {
+	return strings.HasPrefix(function, "runtime.") ||
+		strings.HasPrefix(function, "sync.") ||
+		strings.HasPrefix(function, "internal/")
+}
+
+// diff menghitung selisih profile sesudah dan sebelum workload, lalu mengelompokkan per call site
+func diff(before, after rawProfile) Profile {
+	cyclesPerSecond := after.cyclesPerSecond
+	if cyclesPerSecond <= 0 {
+		cyclesPerSecond = 1e9
+	}
+
+	sites := map[string]*Site{}
+	result := Profile{}
+	for key, current := range after.records {
+		previous := before.records[key]
+		count := current.count - previous.count
+		cycles := current.cycles - previous.cycles
+		if count <= 0 && cycles <= 0 {
+			continue
+		}
+
+		delay := time.Duration(float64(cycles) / cyclesPerSecond * float64(time.Second))
+		siteKey := current.blocker + "|" + current.function + ":" + strconv.Itoa(current.line)
+		site, ok := sites[siteKey]
+		if !ok {
+			site = &Site{
+				Blocker:  current.blocker,
+				Function: current.function,
+				File:     current.file,
+				Line:     current.line,
+			}
+			sites[siteKey] = site
+		}
+		site.Events += count
+		site.Delay += delay
+		result.Events += count
+		result.Delay += delay
+	}
+
+	for _, site := range sites {
+		result.Sites = append(result.Sites, *site)
+	}
+	sort.Slice(result.Sites, func(i, j int) bool {
+		return result.Sites[i].Delay > result.Sites[j].Delay
+	})
+	return result
+}
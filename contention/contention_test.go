@@ -0,0 +1,62 @@
+package contention
+
+import (
+	"bytes"
+	"sync"
+	"testing"
+	"time"
+)
+
+// TestParse memastikan format teks profile di-parsing menjadi call site yang benar
+func TestParse(t *testing.T) {
+	text := `--- mutex:
+cycles/second=1000000000
+sampling period=1
+2000000 2 @ 0x4df0d0 0x4df07a 0x483981
+#	0x4df0cf	sync.(*Mutex).Unlock+0x6f	/usr/local/go/src/sync/mutex.go:65
+#	0x4df079	main.main.func1+0x19		/tmp/p/main.go:7
+
+`
+	profile := parse(bytes.NewBufferString(text))
+	if profile.cyclesPerSecond != 1e9 {
+		t.Fatalf("cycles/second = %v", profile.cyclesPerSecond)
+	}
+
+	summary := diff(rawProfile{}, profile)
+	if summary.Events != 2 || summary.Delay != 2*time.Millisecond {
+		t.Fatalf("summary = %+v", summary)
+	}
+	site := summary.Sites[0]
+	if site.Blocker != "sync.(*Mutex).Unlock" || site.Function != "main.main.func1" || site.Line != 7 {
+		t.Fatalf("site = %+v", site)
+	}
+}
+
+// TestMeasure memastikan contention pada mutex terekam pada call site di dalam workload
+func TestMeasure(t *testing.T) {
+	var mutex sync.Mutex
+	summary := Measure(func() {
+		group := sync.WaitGroup{}
+		for i := 0; i < 5; i++ {
+			group.Add(1)
+			go func() {
+				defer group.Done()
+				mutex.Lock()
+				time.Sleep(5 * time.Millisecond)
+				mutex.Unlock()
+			}()
+		}
+		group.Wait()
+	})
+
+	locks := summary.Block.Locks()
+	if locks.Events == 0 || locks.Delay <= 0 {
+		t.Fatalf("tidak ada contention yang terekam: %+v", summary.Block)
+	}
+	if summary.Mutex.Events == 0 {
+		t.Fatalf("mutex profile kosong: %+v", summary.Mutex)
+	}
+	for _, site := range locks.Top(3) {
+		t.Logf("%s di %s:%d events=%d delay=%v", site.Blocker, site.Function, site.Line, site.Events, site.Delay)
+	}
+}
@@ -0,0 +1,210 @@
+// Package debug berisi http.Handler yang menampilkan metric primitive di repository ini
+// (hit rate pool, waktu tunggu lock, counter, saldo rekening, timer yang masih menunggu, dan
+// jumlah goroutine) sebagai JSON bergaya expvar atau teks Prometheus, sehingga kondisinya dapat
+// di-scrape dari satu tempat ketika primitive tersebut dipakai di dalam service
+package debug
+
+import (
+	"belajar-golang-goroutines/syncx"
+	"belajar-golang-goroutines/timex"
+	"encoding/json"
+	"net/http"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+)
+
+// InstrumentedLock adalah lock yang metric-nya dapat dibaca, dipenuhi oleh syncx.InstrumentedMutex,
+// syncx.InstrumentedRWMutex, dan tipe bank yang meng-embed keduanya. Jika lock juga memiliki
+// ReadStats seperti syncx.InstrumentedRWMutex, metric mode read ikut ditampilkan
+type InstrumentedLock interface {
+	Stats() syncx.LockStats
+}
+
+// readLock adalah lock yang juga mencatat metric mode read
+type readLock interface {
+	ReadStats() syncx.LockStats
+}
+
+// BalanceReader adalah rekening yang saldonya dapat dibaca dengan aman secara concurrent,
+// dipenuhi oleh bank.Account, bank.MutexAccount, dan bank.InstrumentedAccount
+type BalanceReader interface {
+	GetBalance() int
+}
+
+// PoolSnapshot adalah statistik satu pool beserta hit rate-nya
+type PoolSnapshot struct {
+	syncx.PoolStats
+	HitRate float64
+}
+
+// LockSnapshot adalah ringkasan metric satu lock untuk satu mode akses
+type LockSnapshot struct {
+	Name        string
+	Mode        string
+	Contended   uint64
+	Uncontended uint64
+	WaitTotal   time.Duration // Total waktu tunggu sebelum lock diperoleh
+	HoldTotal   time.Duration // Total lama lock ditahan, hanya mode write
+}
+
+// Snapshot adalah seluruh metric pada satu titik waktu, bentuk JSON-nya mengikuti /debug/vars
+// milik expvar: satu objek dengan satu key untuk setiap kelompok variabel
+type Snapshot struct {
+	Goroutines int                         `json:"goroutines"`
+	Gomaxprocs int                         `json:"gomaxprocs"`
+	CPUs       int                         `json:"cpus"`
+	Counters   map[string]int64            `json:"counters"`
+	Pools      map[string]PoolSnapshot     `json:"pools"`
+	Locks      []LockSnapshot              `json:"locks"`
+	Accounts   map[string]int              `json:"accounts"`
+	Timers     map[string]timex.TimerStats `json:"timers"`
+}
+
+type namedCounter struct {
+	name  string
+	value func() int64
+}
+
+type namedPool struct {
+	name string
+	pool *syncx.InstrumentedPool
+}
+
+type namedAccount struct {
+	name    string
+	account BalanceReader
+}
+
+type namedTimers struct {
+	name   string
+	timers *timex.TimerGroup
+}
+
+// Registry menyimpan primitive yang metric-nya ditampilkan. Registry memenuhi http.Handler:
+// path yang berakhiran "metrics" atau query format=prometheus menghasilkan teks Prometheus,
+// selain itu JSON. Zero value siap dipakai
+type Registry struct {
+	mutex    sync.Mutex
+	counters []namedCounter
+	pools    []namedPool
+	locks    []InstrumentedLock
+	accounts []namedAccount
+	timers   []namedTimers
+}
+
+// New membuat Registry kosong
+func New() *Registry {
+	return &Registry{}
+}
+
+// AddCounter menambahkan counter, misalnya x.Load dari atomic.Int64 pada contoh atomic,
+// atau fungsi yang membaca counter di bawah mutex pada contoh mutex
+func (registry *Registry) AddCounter(name string, value func() int64) {
+	registry.mutex.Lock()
+	defer registry.mutex.Unlock()
+	registry.counters = append(registry.counters, namedCounter{name: name, value: value})
+}
+
+// AddPool menambahkan pool yang hit rate-nya ditampilkan
+func (registry *Registry) AddPool(name string, pool *syncx.InstrumentedPool) {
+	registry.mutex.Lock()
+	defer registry.mutex.Unlock()
+	registry.pools = append(registry.pools, namedPool{name: name, pool: pool})
+}
+
+// AddLock menambahkan lock yang waktu tunggunya ditampilkan, nama diambil dari LockStats.Name
+func (registry *Registry) AddLock(locks ...InstrumentedLock) {
+	registry.mutex.Lock()
+	defer registry.mutex.Unlock()
+	registry.locks = append(registry.locks, locks...)
+}
+
+// AddAccount menambahkan rekening yang saldonya ditampilkan
+func (registry *Registry) AddAccount(name string, account BalanceReader) {
+	registry.mutex.Lock()
+	defer registry.mutex.Unlock()
+	registry.accounts = append(registry.accounts, namedAccount{name: name, account: account})
+}
+
+// AddTimers menambahkan kelompok timer yang jumlah timer menunggunya ditampilkan
+func (registry *Registry) AddTimers(name string, timers *timex.TimerGroup) {
+	registry.mutex.Lock()
+	defer registry.mutex.Unlock()
+	registry.timers = append(registry.timers, namedTimers{name: name, timers: timers})
+}
+
+// lockStats mengumpulkan LockStats semua lock, termasuk mode read jika ada
+func (registry *Registry) lockStats() []syncx.LockStats {
+	var stats []syncx.LockStats
+	for _, lock := range registry.locks {
+		stats = append(stats, lock.Stats())
+		if reader, ok := lock.(readLock); ok {
+			stats = append(stats, reader.ReadStats())
+		}
+	}
+	return stats
+}
+
+// Snapshot membaca semua metric saat ini
+func (registry *Registry) Snapshot() Snapshot {
+	registry.mutex.Lock()
+	defer registry.mutex.Unlock()
+
+	snapshot := Snapshot{
+		Goroutines: runtime.NumGoroutine(),
+		Gomaxprocs: runtime.GOMAXPROCS(-1),
+		CPUs:       runtime.NumCPU(),
+		Counters:   make(map[string]int64, len(registry.counters)),
+		Pools:      make(map[string]PoolSnapshot, len(registry.pools)),
+		Locks:      []LockSnapshot{},
+		Accounts:   make(map[string]int, len(registry.accounts)),
+		Timers:     make(map[string]timex.TimerStats, len(registry.timers)),
+	}
+	for _, counter := range registry.counters {
+		snapshot.Counters[counter.name] = counter.value()
+	}
+	for _, pool := range registry.pools {
+		stats := pool.pool.Stats()
+		snapshot.Pools[pool.name] = PoolSnapshot{PoolStats: stats, HitRate: stats.HitRate()}
+	}
+	for _, stats := range registry.lockStats() {
+		snapshot.Locks = append(snapshot.Locks, LockSnapshot{
+			Name:        stats.Name,
+			Mode:        stats.Mode,
+			Contended:   stats.Contended,
+			Uncontended: stats.Uncontended,
+			WaitTotal:   stats.Wait.Sum,
+			HoldTotal:   stats.Hold.Sum,
+		})
+	}
+	for _, account := range registry.accounts {
+		snapshot.Accounts[account.name] = account.account.GetBalance()
+	}
+	for _, timers := range registry.timers {
+		snapshot.Timers[timers.name] = timers.timers.Stats()
+	}
+	return snapshot
+}
+
+// ServeHTTP menulis metric sebagai JSON atau teks Prometheus
+func (registry *Registry) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	format := request.URL.Query().Get("format")
+	if format == "" && strings.HasSuffix(request.URL.Path, "metrics") {
+		format = "prometheus"
+	}
+
+	switch format {
+	case "", "json":
+		writer.Header().Set("Content-Type", "application/json; charset=utf-8")
+		encoder := json.NewEncoder(writer)
+		encoder.SetIndent("", "  ")
+		encoder.Encode(registry.Snapshot())
+	case "prometheus":
+		writer.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		registry.WritePrometheus(writer)
+	default:
+		http.Error(writer, "format tidak dikenal: "+format, http.StatusBadRequest)
+	}
+}
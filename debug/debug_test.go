@@ -0,0 +1,111 @@
+package debug
+
+import (
+	"belajar-golang-goroutines/bank"
+	"belajar-golang-goroutines/syncx"
+	"belajar-golang-goroutines/timex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newRegistry mendaftarkan satu primitive dari setiap jenis dengan nilai yang sudah diketahui
+func newRegistry(t *testing.T) *Registry {
+	var x atomic.Int64
+	x.Store(100)
+
+	// sync.Pool boleh membuang objek yang di-Put, sehingga hanya jumlah Get dan Put yang pasti
+	pool := &syncx.InstrumentedPool{New: func() any { return "New" }}
+	pool.Put(pool.Get())
+	pool.Get()
+
+	account := &bank.InstrumentedAccount{}
+	account.RWMutex.Name = "account"
+	group := sync.WaitGroup{}
+	for i := 0; i < 10; i++ {
+		group.Add(1)
+		go func() {
+			defer group.Done()
+			account.AddBalance(1)
+		}()
+	}
+	group.Wait()
+
+	timers := &timex.TimerGroup{}
+	timers.AfterFunc(time.Hour, func() {}).Stop()
+	pending := timers.AfterFunc(time.Hour, func() {})
+	t.Cleanup(func() { pending.Stop() })
+
+	registry := New()
+	registry.AddCounter("x", x.Load)
+	registry.AddPool("string", pool)
+	registry.AddLock(&account.RWMutex)
+	registry.AddAccount("Aidil", account)
+	registry.AddTimers("demo", timers)
+	return registry
+}
+
+func get(t *testing.T, handler http.Handler, target string) *httptest.ResponseRecorder {
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, target, nil))
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("%s: status = %d, body = %s", target, recorder.Code, recorder.Body.String())
+	}
+	return recorder
+}
+
+// TestJSON memastikan output JSON berisi setiap kelompok metric
+func TestJSON(t *testing.T) {
+	recorder := get(t, newRegistry(t), "/debug/vars")
+	if content := recorder.Header().Get("Content-Type"); !strings.HasPrefix(content, "application/json") {
+		t.Fatalf("Content-Type = %s", content)
+	}
+
+	var snapshot Snapshot
+	if err := json.Unmarshal(recorder.Body.Bytes(), &snapshot); err != nil {
+		t.Fatal(err)
+	}
+	if snapshot.Goroutines == 0 || snapshot.Gomaxprocs == 0 || snapshot.Counters["x"] != 100 || snapshot.Accounts["Aidil"] != 10 {
+		t.Fatalf("snapshot = %+v", snapshot)
+	}
+	if pool := snapshot.Pools["string"]; pool.Gets != 2 || pool.Puts != 1 || pool.Hits()+pool.Misses != 2 {
+		t.Fatalf("pool = %+v", pool)
+	}
+	if timers := snapshot.Timers["demo"]; timers != (timex.TimerStats{Outstanding: 1, Stopped: 1}) {
+		t.Fatalf("timers = %+v", timers)
+	}
+	if len(snapshot.Locks) != 2 || snapshot.Locks[0].Mode != "write" || snapshot.Locks[0].Contended+snapshot.Locks[0].Uncontended != 10 {
+		t.Fatalf("locks = %+v", snapshot.Locks)
+	}
+}
+
+// TestPrometheus memastikan path /metrics dan query format=prometheus menghasilkan teks Prometheus
+func TestPrometheus(t *testing.T) {
+	registry := newRegistry(t)
+	for _, target := range []string{"/metrics", "/debug/vars?format=prometheus"} {
+		body := get(t, registry, target).Body.String()
+		for _, expected := range []string{
+			"runtime_goroutines ",
+			`debug_counter_value{counter="x"} 100`,
+			`syncx_pool_gets_total{pool="string"} 2`,
+			`bank_account_balance{account="Aidil"} 10`,
+			`timex_timers_outstanding{timers="demo"} 1`,
+			`syncx_lock_wait_seconds_count{lock="account",mode="write"} 10`,
+		} {
+			if !strings.Contains(body, expected) {
+				t.Fatalf("%s tidak berisi %q:\n%s", target, expected, body)
+			}
+		}
+	}
+
+	recorder := httptest.NewRecorder()
+	registry.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/?format=xml", nil))
+	if recorder.Code != http.StatusBadRequest {
+		t.Fatalf("status = %d", recorder.Code)
+	}
+}
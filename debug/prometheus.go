@@ -0,0 +1,92 @@
+package debug
+
+import (
+	"belajar-golang-goroutines/syncx"
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+)
+
+// WritePrometheus menulis semua metric dalam format teks Prometheus (exposition format 0.0.4).
+// Metric lock ditulis oleh syncx.WritePrometheus sehingga histogram waktu tunggu ikut tersedia
+func (registry *Registry) WritePrometheus(w io.Writer) error {
+	snapshot := registry.Snapshot()
+	registry.mutex.Lock()
+	locks := registry.lockStats()
+	registry.mutex.Unlock()
+
+	writer := bufio.NewWriter(w)
+	gauge(writer, "runtime_goroutines", "Jumlah goroutine dari runtime.NumGoroutine.")
+	fmt.Fprintf(writer, "runtime_goroutines %d\n", snapshot.Goroutines)
+	gauge(writer, "runtime_gomaxprocs", "Nilai GOMAXPROCS saat ini.")
+	fmt.Fprintf(writer, "runtime_gomaxprocs %d\n", snapshot.Gomaxprocs)
+	gauge(writer, "runtime_cpus", "Jumlah CPU dari runtime.NumCPU.")
+	fmt.Fprintf(writer, "runtime_cpus %d\n", snapshot.CPUs)
+
+	gauge(writer, "debug_counter_value", "Nilai counter yang didaftarkan dengan AddCounter.")
+	for _, name := range sortedKeys(snapshot.Counters) {
+		fmt.Fprintf(writer, "debug_counter_value{counter=\"%s\"} %d\n", syncx.EscapeLabel(name), snapshot.Counters[name])
+	}
+
+	pools := sortedKeys(snapshot.Pools)
+	counter(writer, "syncx_pool_gets_total", "Jumlah Get pada InstrumentedPool.")
+	for _, name := range pools {
+		fmt.Fprintf(writer, "syncx_pool_gets_total{pool=\"%s\"} %d\n", syncx.EscapeLabel(name), snapshot.Pools[name].Gets)
+	}
+	counter(writer, "syncx_pool_misses_total", "Jumlah Get yang harus memanggil New.")
+	for _, name := range pools {
+		fmt.Fprintf(writer, "syncx_pool_misses_total{pool=\"%s\"} %d\n", syncx.EscapeLabel(name), snapshot.Pools[name].Misses)
+	}
+	counter(writer, "syncx_pool_puts_total", "Jumlah Put pada InstrumentedPool.")
+	for _, name := range pools {
+		fmt.Fprintf(writer, "syncx_pool_puts_total{pool=\"%s\"} %d\n", syncx.EscapeLabel(name), snapshot.Pools[name].Puts)
+	}
+	gauge(writer, "syncx_pool_hit_ratio", "Perbandingan Get yang memakai ulang objek dengan seluruh Get.")
+	for _, name := range pools {
+		fmt.Fprintf(writer, "syncx_pool_hit_ratio{pool=\"%s\"} %s\n", syncx.EscapeLabel(name), strconv.FormatFloat(snapshot.Pools[name].HitRate, 'g', -1, 64))
+	}
+
+	gauge(writer, "bank_account_balance", "Saldo rekening.")
+	for _, name := range sortedKeys(snapshot.Accounts) {
+		fmt.Fprintf(writer, "bank_account_balance{account=\"%s\"} %d\n", syncx.EscapeLabel(name), snapshot.Accounts[name])
+	}
+
+	timers := sortedKeys(snapshot.Timers)
+	gauge(writer, "timex_timers_outstanding", "Timer yang belum berbunyi dan belum dihentikan.")
+	for _, name := range timers {
+		fmt.Fprintf(writer, "timex_timers_outstanding{timers=\"%s\"} %d\n", syncx.EscapeLabel(name), snapshot.Timers[name].Outstanding)
+	}
+	counter(writer, "timex_timers_fired_total", "Timer yang fungsinya sudah dijalankan.")
+	for _, name := range timers {
+		fmt.Fprintf(writer, "timex_timers_fired_total{timers=\"%s\"} %d\n", syncx.EscapeLabel(name), snapshot.Timers[name].Fired)
+	}
+	counter(writer, "timex_timers_stopped_total", "Timer yang dihentikan sebelum berbunyi.")
+	for _, name := range timers {
+		fmt.Fprintf(writer, "timex_timers_stopped_total{timers=\"%s\"} %d\n", syncx.EscapeLabel(name), snapshot.Timers[name].Stopped)
+	}
+
+	if err := writer.Flush(); err != nil {
+		return err
+	}
+	return syncx.WritePrometheus(w, locks...)
+}
+
+func gauge(writer io.Writer, name, help string) {
+	fmt.Fprintf(writer, "# HELP %s %s\n# TYPE %s gauge\n", name, help, name)
+}
+
+func counter(writer io.Writer, name, help string) {
+	fmt.Fprintf(writer, "# HELP %s %s\n# TYPE %s counter\n", name, help, name)
+}
+
+// sortedKeys mengembalikan key map secara terurut agar output Prometheus stabil
+func sortedKeys[V any](values map[string]V) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
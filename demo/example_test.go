@@ -0,0 +1,104 @@
+package demo_test
+
+import (
+	"belajar-golang-goroutines/demo"
+	"belajar-golang-goroutines/syncx"
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// countingLocker menghitung berapa kali Lock berhasil dan mengirim jumlahnya ke locked
+type countingLocker struct {
+	sync.Mutex
+	count  int
+	locked chan int
+}
+
+func (locker *countingLocker) Lock() {
+	locker.Mutex.Lock()
+	locker.count++
+	locker.locked <- locker.count
+}
+
+// ExampleWaitCondition adalah versi TestCond dengan Broadcast: semua goroutine
+// dibangunkan sekaligus setelah mulai menunggu
+func ExampleWaitCondition() {
+	locker := &countingLocker{locked: make(chan int, 10)}
+	cond := sync.NewCond(locker)
+	group := sync.WaitGroup{}
+
+	for i := 0; i < 3; i++ {
+		group.Add(1)
+		go demo.WaitCondition(cond, &group, i, os.Stdout)
+	}
+
+	// Setiap goroutine memegang lock sejak Lock sampai cond.Wait melepasnya. Setelah ketiganya
+	// mengunci, Lock di sini baru berhasil ketika semuanya sudah menunggu, sehingga Broadcast
+	// tidak mungkin terlewat
+	for count := 0; count < 3; count = <-locker.locked {
+	}
+	cond.L.Lock()
+	cond.Broadcast()
+	cond.L.Unlock()
+	group.Wait()
+	// Unordered output:
+	// Done 0
+	// Done 1
+	// Done 2
+}
+
+// ExampleAddToMap adalah versi TestMap
+func ExampleAddToMap() {
+	data := &sync.Map{}
+	group := &sync.WaitGroup{}
+	for i := 0; i < 100; i++ {
+		group.Add(1)
+		go demo.AddToMap(data, i, group)
+	}
+	group.Wait()
+
+	count := 0
+	data.Range(func(key, value any) bool {
+		count++
+		return true
+	})
+	fmt.Println("Jumlah data", count)
+	// Output: Jumlah data 100
+}
+
+// ExampleRunAsynchronous adalah versi TestWaitGroup
+func ExampleRunAsynchronous() {
+	group := &sync.WaitGroup{}
+	for i := 0; i < 3; i++ {
+		group.Add(1)
+		go demo.RunAsynchronous(group, os.Stdout, 10*time.Millisecond)
+	}
+	group.Wait()
+	fmt.Println("Selesai")
+	// Output:
+	// Hello
+	// Hello
+	// Hello
+	// Selesai
+}
+
+// ExampleDisplayNumber adalah versi TestManyGoroutineBounded dengan Semaphore
+func ExampleDisplayNumber() {
+	semaphore := syncx.NewSemaphore(1)
+	group := sync.WaitGroup{}
+	for i := 0; i < 3; i++ {
+		group.Add(1)
+		semaphore.Go(context.Background(), 1, func() {
+			defer group.Done()
+			demo.DisplayNumber(os.Stdout, i)
+		})
+	}
+	group.Wait()
+	// Output:
+	// Display 0
+	// Display 1
+	// Display 2
+}
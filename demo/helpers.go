@@ -0,0 +1,43 @@
+// Package demo berisi helper kecil yang dipakai test dan contoh goroutine, sync.Cond, sync.Map,
+// dan WaitGroup. Helper ini hanya untuk demonstrasi, primitive sinkronisasi ada di package syncx
+package demo
+
+import (
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// WaitCondition menunggu sinyal dari cond lalu menuliskan value ke out.
+// Pemanggil harus memanggil group.Add(1) sebelum menjalankan WaitCondition sebagai goroutine.
+// cond.Wait tidak memeriksa kondisi apa pun, sehingga Signal atau Broadcast yang dikirim
+// sebelum goroutine mulai menunggu akan terlewat
+func WaitCondition(cond *sync.Cond, group *sync.WaitGroup, value int, out io.Writer) {
+	defer group.Done()
+
+	cond.L.Lock()
+	cond.Wait()
+	fmt.Fprintln(out, "Done", value)
+	cond.L.Unlock()
+}
+
+// AddToMap menyimpan value ke data sebagai key sekaligus value.
+// Pemanggil harus memanggil group.Add(1) sebelum menjalankan AddToMap sebagai goroutine
+func AddToMap(data *sync.Map, value int, group *sync.WaitGroup) {
+	defer group.Done()
+	data.Store(value, value)
+}
+
+// RunAsynchronous menuliskan "Hello" ke out lalu mensimulasikan proses selama delay.
+// Pemanggil harus memanggil group.Add(1) sebelum menjalankan RunAsynchronous sebagai goroutine
+func RunAsynchronous(group *sync.WaitGroup, out io.Writer, delay time.Duration) {
+	defer group.Done()
+	fmt.Fprintln(out, "Hello")
+	time.Sleep(delay)
+}
+
+// DisplayNumber menuliskan number ke out dengan format "Display [nomor]"
+func DisplayNumber(out io.Writer, number int) {
+	fmt.Fprintln(out, "Display", number)
+}
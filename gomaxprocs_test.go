@@ -2,6 +2,11 @@
 package belajar_golang_goroutines
 
 import (
+	"belajar-golang-goroutines/scenario"
+	"belajar-golang-goroutines/syncx"
+	"belajar-golang-goroutines/tracex"
+	"context"
+	"errors"
 	"fmt"
 	"runtime"
 	"sync"
@@ -76,3 +81,82 @@ func TestChangeThreadNumber(t *testing.T) {
 	group.Wait()
 }
 
+// TestGetGomaxprocsBounded sama seperti TestGetGomaxprocs, tetapi goroutine dibatasi
+// oleh semaphore sebanyak -inflight sehingga jumlah goroutine yang terlihat ikut terbatas
+func TestGetGomaxprocsBounded(t *testing.T) {
+	semaphore := syncx.NewSemaphore(int64(*inFlight))
+	group := sync.WaitGroup{}
+
+	// Launcher berjalan di goroutine terpisah karena Go akan menunggu ketika semaphore penuh
+	group.Add(1)
+	go func() {
+		defer group.Done()
+		for i := 0; i < 100; i++ {
+			group.Add(1)
+			semaphore.Go(context.Background(), 1, func() {
+				// Simulasi pekerjaan dengan sleep selama 1 detik
+				time.Sleep(1 * time.Second)
+				group.Done()
+			})
+		}
+	}()
+	time.Sleep(100 * time.Millisecond)
+
+	fmt.Println("Total CPU", runtime.NumCPU())
+	fmt.Println("Total Thread", runtime.GOMAXPROCS(-1))
+	// Jumlah goroutine sekarang paling banyak -inflight ditambah goroutine milik test
+	fmt.Println("Total Goroutine", runtime.NumGoroutine())
+
+	group.Wait()
+}
+
+// TestTraceGomaxprocs merekam skenario deadlock dengan runtime/trace pada GOMAXPROCS 1 dan 20,
+// lalu membandingkan ringkasan waktu tunggu lock dari file trace. Kedua transfer selalu saling
+// menunggu "lock user2" sampai timeout, sehingga menambah GOMAXPROCS tidak mengubah waktu tunggunya.
+// GOMAXPROCS awal dibuat berbeda dari kedua nilai tersebut, karena test lain seperti
+// TestChangeThreadNumber mengubahnya tanpa memulihkan
+func TestTraceGomaxprocs(t *testing.T) {
+	previous := runtime.GOMAXPROCS(4)
+	t.Cleanup(func() { runtime.GOMAXPROCS(previous) })
+
+	deadlock, _ := scenario.Lookup("deadlock")
+	config := scenario.Config{Delay: 10 * time.Millisecond, Timeout: 100 * time.Millisecond}
+	var waits []time.Duration
+	for _, procs := range []int{1, 20} {
+		config.Procs = procs
+		summary, err := tracex.Run(context.Background(), "deadlock", func(ctx context.Context) {
+			deadlock.Run(ctx, config)
+		})
+		if errors.Is(err, tracex.ErrUnsupportedVersion) {
+			t.Skip(err)
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		fmt.Println("GOMAXPROCS", summary.Procs, "goroutine dibuat", summary.GoroutinesCreated)
+		for _, region := range summary.Regions {
+			fmt.Println("  Region", region.Name, "sync", region.Sync, "channel", region.Channel, "lainnya", region.Other)
+		}
+
+		if summary.Procs != procs {
+			t.Fatalf("GOMAXPROCS di trace = %d, seharusnya %d", summary.Procs, procs)
+		}
+		// Lock pertama setiap transfer tidak diperebutkan, lock kedua tidak pernah diperoleh
+		if lock := summary.Region("lock user1"); lock.Count != 2 || lock.Sync >= config.Delay {
+			t.Fatalf("GOMAXPROCS %d: lock user1 = %+v", procs, lock)
+		}
+		lock := summary.Region("lock user2")
+		if lock.Count != 0 || lock.Sync < 2*(config.Timeout-2*config.Delay) {
+			t.Fatalf("GOMAXPROCS %d: lock user2 = %+v", procs, lock)
+		}
+		if transfer := summary.Region("transfer"); transfer.Other < 2*config.Delay {
+			t.Fatalf("GOMAXPROCS %d: transfer = %+v", procs, transfer)
+		}
+		waits = append(waits, lock.Sync)
+	}
+
+	if difference := waits[1] - waits[0]; difference > 2*config.Delay || difference < -2*config.Delay {
+		t.Fatalf("waktu tunggu lock user2 berbeda terlalu jauh: %v dan %v", waits[0], waits[1])
+	}
+}
@@ -2,11 +2,21 @@
 package belajar_golang_goroutines
 
 import (
+	"belajar-golang-goroutines/demo"
+	"belajar-golang-goroutines/syncx"
+	"context"
+	"flag"
 	"fmt"
+	"os"
+	"sync"
 	"testing"
 	"time"
 )
 
+// inFlight membatasi jumlah goroutine yang berjalan bersamaan pada demo versi terbatas,
+// dapat diubah dengan: go test -run Bounded -v -inflight 10
+var inFlight = flag.Int("inflight", 100, "jumlah maksimal goroutine yang berjalan bersamaan")
+
 // RunHelloWorld adalah fungsi sederhana yang mencetak "Hello World"
 // Fungsi ini digunakan sebagai contoh dasar penggunaan goroutine
 func RunHelloWorld() {
@@ -25,22 +35,34 @@ func TestCreateGoroutine(t *testing.T) {
 	time.Sleep(1 * time.Second)
 }
 
-// DisplayNumber mencetak nomor yang diberikan dengan format "Display [nomor]"
-// Parameter:
-//   - number: nilai integer yang akan dicetak
-func DisplayNumber(number int) {
-	fmt.Println("Display", number)
-}
-
 // TestManyGoroutine menguji pembuatan banyak goroutine secara bersamaan
 // Test ini mendemonstrasikan kemampuan Go untuk menangani ribuan goroutine
 func TestManyGoroutine(t *testing.T) {
 	// Membuat 100000 goroutine secara bersamaan
 	for i := 0; i < 100000; i++ {
-		go DisplayNumber(i)
+		go demo.DisplayNumber(os.Stdout, i)
 	}
 
 	// Menunggu 5 detik untuk memastikan semua goroutine selesai
 	// Catatan: Dalam praktik nyata, sebaiknya gunakan WaitGroup untuk sinkronisasi
 	time.Sleep(5 * time.Second)
 }
+
+// TestManyGoroutineBounded menjalankan TestManyGoroutine dengan semaphore, sehingga
+// hanya -inflight goroutine yang hidup bersamaan walaupun total pekerjaan tetap 100000
+func TestManyGoroutineBounded(t *testing.T) {
+	semaphore := syncx.NewSemaphore(int64(*inFlight))
+	group := sync.WaitGroup{}
+
+	for i := 0; i < 100000; i++ {
+		group.Add(1)
+		// Go akan menunggu sampai ada slot kosong sebelum membuat goroutine baru
+		semaphore.Go(context.Background(), 1, func() {
+			defer group.Done()
+			demo.DisplayNumber(os.Stdout, i)
+		})
+	}
+
+	// Tidak perlu time.Sleep, WaitGroup menunggu semua goroutine selesai
+	group.Wait()
+}
@@ -2,26 +2,14 @@
 package belajar_golang_goroutines
 
 import (
+	"belajar-golang-goroutines/cache"
+	"belajar-golang-goroutines/demo"
 	"fmt"
 	"sync"
 	"testing"
+	"time"
 )
 
-// AddToMap adalah fungsi yang digunakan untuk menambahkan data ke sync.Map secara concurrent
-// Parameters:
-//   - data: pointer ke sync.Map yang akan diisi
-//   - value: nilai integer yang akan disimpan sebagai key dan value
-//   - group: pointer ke WaitGroup untuk sinkronisasi goroutine
-func AddToMap(data *sync.Map, value int, group *sync.WaitGroup) {
-	// Pastikan group.Done() dipanggil ketika fungsi selesai
-	defer group.Done()
-
-	// Tambahkan counter ke WaitGroup
-	group.Add(1)
-	// Simpan data ke sync.Map dengan key dan value yang sama
-	data.Store(value, value)
-}
-
 // TestMap adalah fungsi test untuk mendemonstrasikan penggunaan sync.Map dalam concurrent programming
 // Test ini menunjukkan bagaimana menyimpan 100 angka ke dalam sync.Map secara bersamaan
 // menggunakan goroutine
@@ -33,8 +21,10 @@ func TestMap(t *testing.T) {
 
 	// Loop untuk membuat 100 goroutine
 	for i := 0; i < 100; i++ {
+		// Tambahkan counter ke WaitGroup sebelum goroutine dijalankan
+		group.Add(1)
 		// Jalankan AddToMap sebagai goroutine
-		go AddToMap(data, i, group)
+		go demo.AddToMap(data, i, group)
 	}
 
 	// Tunggu sampai semua goroutine selesai
@@ -46,3 +36,31 @@ func TestMap(t *testing.T) {
 		return true
 	})
 }
+
+// TestCache adalah versi TestMap dengan cache.Cache: jumlah entry dibatasi 10 sehingga
+// entry yang paling lama tidak dipakai dibuang, berbeda dengan sync.Map yang menyimpan semuanya
+func TestCache(t *testing.T) {
+	data := cache.New(cache.Options[int, int]{
+		MaxEntries: 10,
+		TTL:        time.Minute,
+		OnEvict: func(key int, value int, reason cache.EvictReason) {
+			fmt.Println("Dibuang", key, reason)
+		},
+	})
+	// Menghentikan janitor TTL setelah test selesai
+	defer data.Close()
+
+	// Menyimpan 20 angka secara berurutan agar urutan pembuangan terlihat jelas
+	for i := 0; i < 20; i++ {
+		data.Set(i, i)
+	}
+
+	// Angka 0 sudah dibuang, angka 19 masih ada
+	_, found := data.Get(0)
+	fmt.Println("Angka 0 ditemukan:", found)
+	value, found := data.Get(19)
+	fmt.Println("Angka 19 ditemukan:", found, value)
+
+	stats := data.Stats()
+	fmt.Println("Jumlah", stats.Len, "Hit rate", stats.HitRate())
+}
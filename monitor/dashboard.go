@@ -0,0 +1,147 @@
+package monitor
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// Kode escape ANSI yang dipakai dashboard
+const (
+	ansiHome       = "\x1b[H"
+	ansiClear      = "\x1b[2J"
+	ansiClearLine  = "\x1b[K"
+	ansiClearBelow = "\x1b[J"
+	ansiHideCursor = "\x1b[?25l"
+	ansiShowCursor = "\x1b[?25h"
+	ansiBold       = "\x1b[1m"
+	ansiRed        = "\x1b[31m"
+	ansiGreen      = "\x1b[32m"
+	ansiYellow     = "\x1b[33m"
+	ansiReset      = "\x1b[0m"
+)
+
+// sparkLevels adalah karakter grafik riwayat jumlah goroutine, dari rendah ke tinggi
+var sparkLevels = []rune("▁▂▃▄▅▆▇█")
+
+// Dashboard menggambar Snapshot dari Monitor ke terminal secara berkala
+type Dashboard struct {
+	Monitor  *Monitor
+	Out      io.Writer
+	Title    string
+	Interval time.Duration // Jeda antar frame, default 200 milidetik
+	Width    int           // Lebar bar dan grafik, default 40
+
+	history []int
+}
+
+// Run menggambar frame setiap Interval sampai ctx selesai, lalu menggambar frame terakhir
+// dan menampilkan kursor kembali
+func (dashboard *Dashboard) Run(ctx context.Context) {
+	interval := dashboard.Interval
+	if interval <= 0 {
+		interval = 200 * time.Millisecond
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	fmt.Fprint(dashboard.Out, ansiHideCursor+ansiClear)
+	defer fmt.Fprint(dashboard.Out, ansiShowCursor)
+	dashboard.Render(dashboard.Monitor.Sample())
+	for {
+		select {
+		case <-ctx.Done():
+			dashboard.Render(dashboard.Monitor.Sample())
+			return
+		case <-ticker.C:
+			dashboard.Render(dashboard.Monitor.Sample())
+		}
+	}
+}
+
+// Render menggambar satu frame. Kursor dipindah ke pojok kiri atas dan setiap baris dihapus
+// sampai ujung, sehingga frame baru menimpa frame lama tanpa berkedip
+func (dashboard *Dashboard) Render(snapshot Snapshot) {
+	width := dashboard.Width
+	if width <= 0 {
+		width = 40
+	}
+	dashboard.history = append(dashboard.history, snapshot.Goroutines)
+	if len(dashboard.history) > width {
+		dashboard.history = dashboard.history[len(dashboard.history)-width:]
+	}
+
+	var builder strings.Builder
+	line := func(format string, args ...any) {
+		fmt.Fprintf(&builder, format, args...)
+		builder.WriteString(ansiClearLine + "\n")
+	}
+
+	builder.WriteString(ansiHome)
+	line("%s%s%s  %s", ansiBold, dashboard.Title, ansiReset, snapshot.At.Format("15:04:05.000"))
+	line("")
+	line("%sGoroutine%s %d  %s", ansiBold, ansiReset, snapshot.Goroutines, sparkline(dashboard.history))
+	line("")
+
+	line("%sState goroutine%s", ansiBold, ansiReset)
+	for _, state := range snapshot.States {
+		line("  %-24s %6d %s", state.State, state.Count, bar(state.Count, snapshot.Goroutines, width))
+	}
+
+	if len(snapshot.Locks) > 0 {
+		line("")
+		line("%sLock%s", ansiBold, ansiReset)
+		for _, lock := range snapshot.Locks {
+			status := ansiGreen + "bebas" + ansiReset
+			if lock.Held {
+				color := ansiYellow
+				if lock.Waiters > 0 {
+					color = ansiRed
+				}
+				status = fmt.Sprintf("%sdipegang goroutine %d selama %s%s", color, lock.Holder, lock.HeldFor.Round(time.Millisecond), ansiReset)
+			}
+			line("  %-16s %s, reader %d, menunggu %d", lock.Name, status, lock.Readers, lock.Waiters)
+		}
+	}
+
+	if len(snapshot.Channels) > 0 {
+		line("")
+		line("%sChannel%s", ansiBold, ansiReset)
+		for _, channel := range snapshot.Channels {
+			line("  %-16s %5d/%-5d %s", channel.Name, channel.Len, channel.Capacity, bar(channel.Len, channel.Capacity, width))
+		}
+	}
+	builder.WriteString(ansiClearBelow)
+
+	io.WriteString(dashboard.Out, builder.String())
+}
+
+// bar menggambar value/total sebagai bar selebar width karakter
+func bar(value, total, width int) string {
+	if total <= 0 {
+		return ""
+	}
+	filled := min(value*width/total, width)
+	if value > 0 && filled == 0 {
+		filled = 1
+	}
+	return strings.Repeat("█", filled) + strings.Repeat("░", width-filled)
+}
+
+// sparkline menggambar riwayat nilai sebagai satu baris karakter blok
+func sparkline(values []int) string {
+	highest := 0
+	for _, value := range values {
+		highest = max(highest, value)
+	}
+	if highest == 0 {
+		return ""
+	}
+	runes := make([]rune, len(values))
+	for i, value := range values {
+		runes[i] = sparkLevels[value*(len(sparkLevels)-1)/highest]
+	}
+	return string(runes)
+}
@@ -0,0 +1,147 @@
+// Package monitor berisi sampler aktivitas goroutine (jumlah goroutine, state dari stack dump,
+// pemegang lock, dan isi buffer channel) beserta dashboard ANSI untuk terminal tanpa dependensi luar
+package monitor
+
+import (
+	"belajar-golang-goroutines/syncx"
+	"bytes"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// LockWatcher adalah lock yang keadaannya dapat dibaca, dipenuhi oleh syncx.InstrumentedMutex,
+// syncx.InstrumentedRWMutex, dan tipe bank yang meng-embed keduanya
+type LockWatcher interface {
+	State() syncx.LockState
+}
+
+// StateCount adalah jumlah goroutine dengan state tertentu, misalnya "chan receive" atau "sync.Mutex.Lock"
+type StateCount struct {
+	State string
+	Count int
+}
+
+// ChannelState adalah isi buffer sebuah channel
+type ChannelState struct {
+	Name     string
+	Len      int
+	Capacity int
+}
+
+// Snapshot adalah hasil satu kali sampling
+type Snapshot struct {
+	At         time.Time
+	Goroutines int               // Dari runtime.NumGoroutine
+	States     []StateCount      // Terurut dari jumlah terbanyak
+	Locks      []syncx.LockState // Sesuai urutan WatchLock
+	Channels   []ChannelState    // Sesuai urutan WatchChannel
+}
+
+// channelWatch adalah channel terdaftar beserta fungsi pembaca isinya
+type channelWatch struct {
+	name      string
+	occupancy func() (length, capacity int)
+}
+
+// Monitor menyimpan lock dan channel yang diamati. Zero value siap dipakai
+type Monitor struct {
+	mutex    sync.Mutex
+	locks    []LockWatcher
+	channels []channelWatch
+	buffer   []byte
+}
+
+// New membuat Monitor kosong
+func New() *Monitor {
+	return &Monitor{}
+}
+
+// WatchLock menambahkan lock yang akan ditampilkan pemegang dan antreannya
+func (monitor *Monitor) WatchLock(locks ...LockWatcher) {
+	monitor.mutex.Lock()
+	defer monitor.mutex.Unlock()
+	monitor.locks = append(monitor.locks, locks...)
+}
+
+// WatchChannel menambahkan channel yang akan ditampilkan isi buffer-nya lewat fungsi occupancy
+func (monitor *Monitor) WatchChannel(name string, occupancy func() (length, capacity int)) {
+	monitor.mutex.Lock()
+	defer monitor.mutex.Unlock()
+	monitor.channels = append(monitor.channels, channelWatch{name: name, occupancy: occupancy})
+}
+
+// WatchChan menambahkan channel bertipe apa pun ke monitor. Berupa fungsi karena method
+// tidak boleh punya type parameter sendiri
+func WatchChan[T any](monitor *Monitor, name string, channel chan T) {
+	monitor.WatchChannel(name, func() (int, int) { return len(channel), cap(channel) })
+}
+
+// Sample mengambil snapshot saat ini. Stack dump semua goroutine menghentikan program sesaat
+// (stop the world), sehingga Sample sebaiknya tidak dipanggil lebih sering dari beberapa kali per detik
+func (monitor *Monitor) Sample() Snapshot {
+	monitor.mutex.Lock()
+	defer monitor.mutex.Unlock()
+
+	snapshot := Snapshot{At: time.Now(), Goroutines: runtime.NumGoroutine()}
+	snapshot.States = countStates(monitor.stacks())
+	for _, lock := range monitor.locks {
+		snapshot.Locks = append(snapshot.Locks, lock.State())
+	}
+	for _, channel := range monitor.channels {
+		length, capacity := channel.occupancy()
+		snapshot.Channels = append(snapshot.Channels, ChannelState{Name: channel.name, Len: length, Capacity: capacity})
+	}
+	return snapshot
+}
+
+// stacks mengambil stack dump semua goroutine, memperbesar buffer sampai cukup.
+// Pemanggil harus memegang mutex karena buffer dipakai ulang
+func (monitor *Monitor) stacks() []byte {
+	if monitor.buffer == nil {
+		monitor.buffer = make([]byte, 64*1024)
+	}
+	for {
+		n := runtime.Stack(monitor.buffer, true)
+		if n < len(monitor.buffer) {
+			return monitor.buffer[:n]
+		}
+		monitor.buffer = make([]byte, 2*len(monitor.buffer))
+	}
+}
+
+// countStates menghitung state dari header setiap goroutine di stack dump,
+// misalnya "goroutine 7 [sync.Mutex.Lock, 2 minutes]:" menjadi "sync.Mutex.Lock"
+func countStates(dump []byte) []StateCount {
+	counts := make(map[string]int)
+	for _, line := range bytes.Split(dump, []byte("\n")) {
+		if !bytes.HasPrefix(line, []byte("goroutine ")) {
+			continue
+		}
+		start := bytes.IndexByte(line, '[')
+		end := bytes.LastIndexByte(line, ']')
+		if start < 0 || end < start {
+			continue
+		}
+		state := string(line[start+1 : end])
+		// Keterangan tambahan seperti lama menunggu atau "locked to thread" diabaikan
+		if comma := strings.IndexByte(state, ','); comma >= 0 {
+			state = state[:comma]
+		}
+		counts[state]++
+	}
+
+	states := make([]StateCount, 0, len(counts))
+	for state, count := range counts {
+		states = append(states, StateCount{State: state, Count: count})
+	}
+	sort.Slice(states, func(i, j int) bool {
+		if states[i].Count != states[j].Count {
+			return states[i].Count > states[j].Count
+		}
+		return states[i].State < states[j].State
+	})
+	return states
+}
@@ -0,0 +1,98 @@
+package monitor
+
+import (
+	"belajar-golang-goroutines/bank"
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestCountStates memastikan keterangan tambahan di header goroutine diabaikan
+func TestCountStates(t *testing.T) {
+	dump := []byte(`goroutine 1 [running]:
+main.main()
+goroutine 7 [sync.Mutex.Lock, 2 minutes]:
+sync.runtime_SemacquireMutex()
+goroutine 8 [sync.Mutex.Lock]:
+goroutine 9 [chan receive, locked to thread]:
+`)
+	states := countStates(dump)
+	expected := []StateCount{{"sync.Mutex.Lock", 2}, {"chan receive", 1}, {"running", 1}}
+	if len(states) != len(expected) {
+		t.Fatalf("states = %v", states)
+	}
+	for i := range expected {
+		if states[i] != expected[i] {
+			t.Fatalf("states = %v, seharusnya %v", states, expected)
+		}
+	}
+}
+
+// TestSample menjalankan skenario TestDeadlock dengan rekening instrumented dan memastikan
+// snapshot menunjukkan kedua lock dipegang dan masing-masing ditunggu satu goroutine
+func TestSample(t *testing.T) {
+	user1 := bank.NewInstrumentedUserBalance("Aidil", 1000000)
+	user2 := bank.NewInstrumentedUserBalance("Budi", 1000000)
+	results := make(chan int, 10)
+	results <- 1
+
+	monitor := New()
+	monitor.WatchLock(user1, user2)
+	WatchChan(monitor, "results", results)
+
+	// Deadlock sengaja dibuat, kedua goroutine tetap macet sampai test selesai
+	go bank.Transfer(io.Discard, user1, user2, 100000, 20*time.Millisecond)
+	go bank.Transfer(io.Discard, user2, user1, 200000, 20*time.Millisecond)
+
+	deadline := time.Now().Add(time.Second)
+	var snapshot Snapshot
+	for time.Now().Before(deadline) {
+		snapshot = monitor.Sample()
+		if snapshot.Locks[0].Waiters == 1 && snapshot.Locks[1].Waiters == 1 {
+			break
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	for _, lock := range snapshot.Locks {
+		if !lock.Held || lock.Holder == 0 || lock.Waiters != 1 {
+			t.Fatalf("lock = %+v", lock)
+		}
+	}
+	if snapshot.Channels[0] != (ChannelState{Name: "results", Len: 1, Capacity: 10}) {
+		t.Fatalf("channel = %+v", snapshot.Channels[0])
+	}
+	found := false
+	for _, state := range snapshot.States {
+		if state.State == "sync.Mutex.Lock" && state.Count >= 2 {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("states = %v", snapshot.States)
+	}
+}
+
+// TestDashboard memastikan frame berisi bagian lock dan channel serta kursor ditampilkan lagi
+func TestDashboard(t *testing.T) {
+	monitor := New()
+	channel := make(chan string, 4)
+	channel <- "Aidil"
+	WatchChan(monitor, "antrian", channel)
+
+	var out bytes.Buffer
+	dashboard := &Dashboard{Monitor: monitor, Out: &out, Title: "demo", Interval: time.Millisecond}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	dashboard.Run(ctx)
+
+	frame := out.String()
+	for _, expected := range []string{"demo", "Goroutine", "antrian", "1/4", ansiShowCursor} {
+		if !strings.Contains(frame, expected) {
+			t.Fatalf("frame tidak berisi %q:\n%s", expected, frame)
+		}
+	}
+}
@@ -2,7 +2,12 @@
 package belajar_golang_goroutines
 
 import (
+	"belajar-golang-goroutines/actor"
+	"belajar-golang-goroutines/bank"
+	"belajar-golang-goroutines/contention"
+	"context"
 	"fmt"
+	"os"
 	"sync"
 	"testing"
 	"time"
@@ -28,33 +33,12 @@ func TestMutex(t *testing.T) {
 	fmt.Println("Counter = ", x)
 }
 
-// BankAccount merepresentasikan rekening bank dengan RWMutex untuk mengamankan akses concurrent
-type BankAccount struct {
-	RWMutex sync.RWMutex // RWMutex untuk membedakan operasi read dan write
-	Balance int          // Saldo rekening
-}
-
-// AddBalance menambahkan sejumlah amount ke saldo rekening dengan menggunakan write lock
-func (account *BankAccount) AddBalance(amount int) {
-	account.RWMutex.Lock()   // Mengunci untuk operasi write
-	account.Balance = account.Balance + amount
-	account.RWMutex.Unlock() // Membuka kunci setelah selesai
-}
-
-// GetBalance mengambil nilai saldo rekening dengan menggunakan read lock
-func (account *BankAccount) GetBalance() int {
-	account.RWMutex.RLock()  // Mengunci untuk operasi read
-	balance := account.Balance
-	account.RWMutex.RUnlock() // Membuka kunci read
-	return balance
-}
-
 // TestRWMutex menguji penggunaan RWMutex dalam operasi concurrent read/write pada rekening bank
 // Test ini mendemonstrasikan bagaimana multiple goroutine dapat mengakses dan memodifikasi saldo
 // secara aman menggunakan RWMutex
 func TestRWMutex(t *testing.T) {
 	// Inisialisasi rekening bank baru dengan saldo awal 0
-	account := BankAccount{}
+	account := bank.Account{}
 
 	// Membuat 100 goroutine yang akan melakukan operasi secara concurrent
 	// Setiap goroutine akan menambah saldo dan membaca saldo sebanyak 100 kali
@@ -73,57 +57,52 @@ func TestRWMutex(t *testing.T) {
 	fmt.Println("Total Balance", account.GetBalance())
 }
 
-// UserBalance merepresentasikan entitas pengguna dengan saldo
-// Struct ini menggunakan embedded mutex untuk thread-safety
-type UserBalance struct {
-	sync.Mutex           // Mutex yang di-embed untuk sinkronisasi akses ke data
-	Name    string      // Nama pemilik rekening
-	Balance int         // Jumlah saldo yang dimiliki
-}
-
-// Lock mengimplementasikan method Lock dari interface sync.Locker
-// Method ini harus dipanggil sebelum mengakses atau memodifikasi data UserBalance
-func (user *UserBalance) Lock() {
-	user.Mutex.Lock()
-}
-
-// Unlock mengimplementasikan method Unlock dari interface sync.Locker
-// Method ini harus dipanggil setelah selesai mengakses atau memodifikasi data UserBalance
-func (user *UserBalance) Unlock() {
-	user.Mutex.Unlock()
+// readHeavyWorkload menjalankan 100 goroutine dengan campuran 1 write banding 9 read,
+// dimana setiap read menahan lock selama 1 milidetik untuk mensimulasikan pembuatan laporan
+func readHeavyWorkload(write func(), read sync.Locker) {
+	group := sync.WaitGroup{}
+	for i := 0; i < 100; i++ {
+		group.Add(1)
+		go func() {
+			defer group.Done()
+			for j := 0; j < 10; j++ {
+				if j == 0 {
+					write()
+					continue
+				}
+				read.Lock()
+				time.Sleep(1 * time.Millisecond)
+				read.Unlock()
+			}
+		}()
+	}
+	group.Wait()
 }
 
-// Change memodifikasi saldo pengguna
-// Method ini mengasumsikan pemanggil sudah melakukan Lock() sebelumnya
-// Parameter amount: jumlah perubahan saldo (positif untuk penambahan, negatif untuk pengurangan)
-func (user *UserBalance) Change(amount int) {
-	user.Balance = user.Balance + amount
-}
+// TestContentionRWMutex membandingkan waktu blocking bank.Account (RWMutex)
+// dengan bank.MutexAccount (Mutex) pada workload yang didominasi operasi read
+func TestContentionRWMutex(t *testing.T) {
+	rwAccount := bank.Account{}
+	rwSummary := contention.Measure(func() {
+		readHeavyWorkload(func() { rwAccount.AddBalance(1) }, rwAccount.RWMutex.RLocker())
+	})
+
+	mutexAccount := bank.MutexAccount{}
+	mutexSummary := contention.Measure(func() {
+		readHeavyWorkload(func() { mutexAccount.AddBalance(1) }, &mutexAccount.Mutex)
+	})
+
+	rwBlocked := rwSummary.Block.Locks()
+	mutexBlocked := mutexSummary.Block.Locks()
+	fmt.Println("RWMutex blocked", rwBlocked.Delay, "events", rwBlocked.Events)
+	fmt.Println("Mutex   blocked", mutexBlocked.Delay, "events", mutexBlocked.Events)
+	for _, site := range mutexBlocked.Top(3) {
+		fmt.Println("  ", site.Blocker, site.Function, site.Line, site.Delay)
+	}
 
-// Transfer melakukan pemindahan dana antar rekening
-// PERINGATAN: Implementasi ini rentan terhadap deadlock karena penguncian tidak berurutan
-// Parameters:
-// - user1: pengirim dana
-// - user2: penerima dana
-// - amount: jumlah yang ditransfer
-func Transfer(user1 *UserBalance, user2 *UserBalance, amount int) {
-	// Mengunci akses ke rekening pengirim
-	user1.Lock()
-	fmt.Println("Lock user1", user1.Name)
-	user1.Change(-amount) // Mengurangi saldo pengirim
-
-	time.Sleep(1 * time.Second) // Simulasi proses yang memakan waktu
-
-	// Mengunci akses ke rekening penerima
-	user2.Lock()
-	fmt.Println("Lock user2", user2.Name)
-	user2.Change(amount) // Menambah saldo penerima
-
-	time.Sleep(1 * time.Second)
-
-	// Membuka kunci kedua rekening
-	user1.Unlock()
-	user2.Unlock()
+	if rwBlocked.Delay >= mutexBlocked.Delay {
+		t.Fatalf("RWMutex seharusnya lebih sedikit blocking: rw=%v mutex=%v", rwBlocked.Delay, mutexBlocked.Delay)
+	}
 }
 
 // TestDeadlock mendemonstrasikan potensi deadlock dalam transfer concurrent
@@ -131,12 +110,12 @@ func Transfer(user1 *UserBalance, user2 *UserBalance, amount int) {
 // menyebabkan deadlock karena pola penguncian yang tidak konsisten
 func TestDeadlock(t *testing.T) {
 	// Inisialisasi dua rekening dengan saldo awal
-	user1 := UserBalance{
+	user1 := bank.UserBalance{
 		Name:    "Aidil",
 		Balance: 1000000,
 	}
 
-	user2 := UserBalance{
+	user2 := bank.UserBalance{
 		Name:    "Budi",
 		Balance: 1000000,
 	}
@@ -144,8 +123,8 @@ func TestDeadlock(t *testing.T) {
 	// Menjalankan dua transfer secara concurrent dengan arah berlawanan
 	// Transfer pertama: Eko -> Budi (100000)
 	// Transfer kedua: Budi -> Eko (200000)
-	go Transfer(&user1, &user2, 100000)
-	go Transfer(&user2, &user1, 200000)
+	go bank.Transfer(os.Stdout, &user1, &user2, 100000, 1*time.Second)
+	go bank.Transfer(os.Stdout, &user2, &user1, 200000, 1*time.Second)
 
 	// Menunggu proses transfer selesai atau terjadi deadlock
 	time.Sleep(10 * time.Second)
@@ -154,3 +133,121 @@ func TestDeadlock(t *testing.T) {
 	fmt.Println("User ", user1.Name, ", Balance ", user1.Balance)
 	fmt.Println("User ", user2.Name, ", Balance ", user2.Balance)
 }
+
+// TestDeadlockBackoff menjalankan skenario TestDeadlock dengan TransferWithBackoff
+// dan memastikan kedua transfer selesai dengan saldo yang benar
+func TestDeadlockBackoff(t *testing.T) {
+	user1 := bank.TimedUserBalance{
+		Name:    "Aidil",
+		Balance: 1000000,
+	}
+
+	user2 := bank.TimedUserBalance{
+		Name:    "Budi",
+		Balance: 1000000,
+	}
+
+	group := sync.WaitGroup{}
+	group.Add(2)
+	go func() {
+		defer group.Done()
+		bank.TransferWithBackoff(os.Stdout, &user1, &user2, 100000, 50*time.Millisecond, 20*time.Millisecond)
+	}()
+	go func() {
+		defer group.Done()
+		bank.TransferWithBackoff(os.Stdout, &user2, &user1, 200000, 50*time.Millisecond, 20*time.Millisecond)
+	}()
+	group.Wait()
+
+	fmt.Println("User ", user1.Name, ", Balance ", user1.Balance)
+	fmt.Println("User ", user2.Name, ", Balance ", user2.Balance)
+
+	if user1.Balance != 1100000 || user2.Balance != 900000 {
+		t.Fatalf("saldo tidak sesuai: %d dan %d", user1.Balance, user2.Balance)
+	}
+}
+
+// TestDeadlockOrdered menjalankan skenario TestDeadlock dengan TransferOrdered yang mengunci
+// rekening berurutan, lalu membaca saldo dengan SnapshotUsers alih-alih membaca Balance tanpa lock
+func TestDeadlockOrdered(t *testing.T) {
+	user1 := bank.UserBalance{
+		Name:    "Aidil",
+		Balance: 1000000,
+	}
+
+	user2 := bank.UserBalance{
+		Name:    "Budi",
+		Balance: 1000000,
+	}
+
+	group := sync.WaitGroup{}
+	group.Add(2)
+	go func() {
+		defer group.Done()
+		bank.TransferOrdered(os.Stdout, &user1, &user2, 100000, 50*time.Millisecond)
+	}()
+	go func() {
+		defer group.Done()
+		bank.TransferOrdered(os.Stdout, &user2, &user1, 200000, 50*time.Millisecond)
+	}()
+	group.Wait()
+
+	balances := bank.SnapshotUsers(&user1, &user2)
+	fmt.Println("User ", user1.Name, ", Balance ", balances[0])
+	fmt.Println("User ", user2.Name, ", Balance ", balances[1])
+
+	if balances[0] != 1100000 || balances[1] != 900000 {
+		t.Fatalf("saldo tidak sesuai: %v", balances)
+	}
+}
+
+// TestDeadlockActor menjalankan skenario TestDeadlock dengan model actor dari package actor.
+// Setiap rekening adalah goroutine dengan mailbox, dan transfer memakai protokol reserve/commit,
+// sehingga tidak ada lock yang saling ditunggu dan deadlock tidak bisa terjadi
+func TestDeadlockActor(t *testing.T) {
+	// Supervisor menjalankan actor dan menghentikannya setelah test selesai
+	supervisor := actor.NewSupervisor(3)
+	defer supervisor.Stop()
+
+	user1 := supervisor.Spawn("Aidil", 1000000)
+	user2 := supervisor.Spawn("Budi", 1000000)
+
+	// Menjalankan dua transfer secara concurrent dengan arah berlawanan
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	group := sync.WaitGroup{}
+	group.Add(2)
+	go func() {
+		defer group.Done()
+		actor.Transfer(ctx, user1, user2, 100000)
+	}()
+	go func() {
+		defer group.Done()
+		actor.Transfer(ctx, user2, user1, 200000)
+	}()
+	group.Wait()
+
+	// Saldo dibaca lewat pesan GetBalance, bukan langsung dari field
+	balance1, _ := user1.Balance(ctx)
+	balance2, _ := user2.Balance(ctx)
+	fmt.Println("User ", user1.Name, ", Balance ", balance1)
+	fmt.Println("User ", user2.Name, ", Balance ", balance2)
+
+	if balance1 != 1100000 || balance2 != 900000 {
+		t.Fatalf("saldo tidak sesuai: %d dan %d", balance1, balance2)
+	}
+}
+
+// TestBankSimulator menjalankan simulasi bank yang sama dengan setiap strategi penguncian
+// dan menampilkan throughput, latency, serta hasil pemeriksaan invariant-nya
+func TestBankSimulator(t *testing.T) {
+	for _, name := range []string{"global", "ordered", "backoff", "actor"} {
+		config := bank.SimulationConfig{Accounts: 10, Workers: 8, Duration: 500 * time.Millisecond}
+		report, err := bank.Simulate(context.Background(), config, bank.Strategies[name])
+		report.Print(os.Stdout)
+		if err != nil {
+			t.Error(err)
+		}
+		fmt.Println()
+	}
+}
@@ -2,34 +2,30 @@
 package belajar_golang_goroutines
 
 import (
+	"belajar-golang-goroutines/singleflight"
 	"fmt"
 	"sync"
 	"testing"
+	"time"
 )
 
-// counter adalah variabel global yang akan diincrement
-var counter = 0
-
-// OnlyOnce adalah fungsi yang akan dieksekusi hanya sekali menggunakan sync.Once
-func OnlyOnce() {
-	counter++
-}
-
 // TestOnce adalah fungsi testing untuk memastikan bahwa sync.Once berfungsi dengan benar
-// dengan menjalankan fungsi OnlyOnce dalam multiple goroutine
+// dengan menjalankan fungsi yang menambah counter dalam multiple goroutine
 func TestOnce(t *testing.T) {
 	// Inisialisasi sync.Once untuk memastikan fungsi hanya dijalankan sekali
 	once := sync.Once{}
+	// Counter yang hanya diincrement sekali oleh fungsi di dalam once.Do
+	counter := 0
 	// Inisialisasi WaitGroup untuk menunggu semua goroutine selesai
 	group := sync.WaitGroup{}
 
 	// Loop untuk membuat 100 goroutine
 	for i := 0; i < 100; i++ {
+		// Menambah counter WaitGroup sebelum goroutine dijalankan
+		group.Add(1)
 		go func() {
-			// Menambah counter WaitGroup sebelum eksekusi
-			group.Add(1)
-			// Menggunakan sync.Once untuk memastikan OnlyOnce hanya dijalankan sekali
-			once.Do(OnlyOnce)
+			// Menggunakan sync.Once untuk memastikan counter hanya diincrement sekali
+			once.Do(func() { counter++ })
 			// Menandakan goroutine telah selesai
 			group.Done()
 		}()
@@ -41,3 +37,33 @@ func TestOnce(t *testing.T) {
 	fmt.Println("Counter", counter)
 }
 
+// TestSingleflight adalah versi TestOnce untuk cache: singleflight.Group menjalankan fungsi
+// sekali per key selama pemanggilan masih berjalan, bukan sekali untuk selamanya
+func TestSingleflight(t *testing.T) {
+	loads := 0
+	flight := singleflight.Group[string, string]{}
+	waitGroup := sync.WaitGroup{}
+
+	// 100 goroutine meminta data yang sama secara bersamaan
+	for i := 0; i < 100; i++ {
+		waitGroup.Add(1)
+		go func() {
+			defer waitGroup.Done()
+			flight.Do("user:1", func() (string, error) {
+				// Hanya satu goroutine yang menjalankan fungsi ini, sehingga loads aman diubah
+				loads++
+				time.Sleep(100 * time.Millisecond)
+				return "Aidil", nil
+			})
+		}()
+	}
+	waitGroup.Wait()
+	fmt.Println("Load pertama", loads)
+
+	// Pemanggilan sebelumnya sudah selesai, sehingga fungsi dijalankan lagi
+	flight.Do("user:1", func() (string, error) {
+		loads++
+		return "Aidil", nil
+	})
+	fmt.Println("Load kedua", loads)
+}
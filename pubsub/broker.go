@@ -0,0 +1,239 @@
+// Package pubsub berisi broker publish/subscribe in-process berbasis channel,
+// generalisasi dari pola GiveMeResponse yang mengirim satu pesan ke satu channel
+package pubsub
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+)
+
+// Policy menentukan apa yang terjadi ketika buffer subscriber penuh
+type Policy int
+
+const (
+	// Block membuat Publish menunggu sampai subscriber membaca pesan
+	Block Policy = iota
+	// DropOldest membuang pesan paling lama di buffer untuk memberi tempat pesan baru.
+	// Subscriber tanpa buffer tidak memiliki pesan lama, sehingga diperlakukan seperti DropNewest
+	DropOldest
+	// DropNewest membuang pesan baru yang tidak muat di buffer
+	DropNewest
+	// Disconnect memutus subscriber yang lambat dengan menutup channel-nya
+	Disconnect
+)
+
+// ErrClosed dikembalikan ketika Broker sudah ditutup
+var ErrClosed = errors.New("pubsub: broker closed")
+
+// Broker mendistribusikan pesan bertipe T ke semua subscriber dari sebuah topic
+type Broker[T any] struct {
+	policy Policy
+
+	mutex  sync.RWMutex
+	topics map[string]map[<-chan T]*subscriber[T]
+	closed bool
+}
+
+// subscriber menyimpan channel milik satu subscriber beserta statistiknya
+type subscriber[T any] struct {
+	topic   string
+	policy  Policy
+	channel chan T
+	done    chan struct{} // Ditutup ketika subscriber dilepas, membangunkan Publish yang sedang block
+
+	sendMutex sync.Mutex // Hanya satu publisher yang mengirim ke channel dalam satu waktu
+	removed   bool       // Dilindungi sendMutex, true setelah channel ditutup
+	closeOnce sync.Once
+
+	delivered atomic.Uint64
+	dropped   atomic.Uint64
+}
+
+// Stats adalah statistik satu subscriber
+type Stats struct {
+	Topic     string // Topic yang di-subscribe
+	Lag       int    // Jumlah pesan di buffer yang belum dibaca
+	Capacity  int    // Ukuran buffer subscriber
+	Delivered uint64 // Jumlah pesan yang berhasil masuk ke buffer
+	Dropped   uint64 // Jumlah pesan yang dibuang karena buffer penuh
+}
+
+// NewBroker membuat broker dengan policy default untuk subscriber yang lambat
+func NewBroker[T any](policy Policy) *Broker[T] {
+	return &Broker[T]{
+		policy: policy,
+		topics: map[string]map[<-chan T]*subscriber[T]{},
+	}
+}
+
+// Subscribe mendaftarkan subscriber baru pada topic dengan policy default broker.
+// Channel yang dikembalikan hanya bisa dibaca (seperti OnlyOut) dan ditutup ketika
+// Unsubscribe, Close, atau subscriber diputus oleh policy Disconnect
+func (broker *Broker[T]) Subscribe(topic string, bufferSize int) (<-chan T, error) {
+	return broker.SubscribeWithPolicy(topic, bufferSize, broker.policy)
+}
+
+// SubscribeWithPolicy sama seperti Subscribe, tetapi dengan policy khusus untuk subscriber ini
+func (broker *Broker[T]) SubscribeWithPolicy(topic string, bufferSize int, policy Policy) (<-chan T, error) {
+	broker.mutex.Lock()
+	defer broker.mutex.Unlock()
+	if broker.closed {
+		return nil, ErrClosed
+	}
+	if policy == DropOldest && bufferSize == 0 {
+		policy = DropNewest
+	}
+
+	sub := &subscriber[T]{
+		topic:   topic,
+		policy:  policy,
+		channel: make(chan T, bufferSize),
+		done:    make(chan struct{}),
+	}
+	if broker.topics[topic] == nil {
+		broker.topics[topic] = map[<-chan T]*subscriber[T]{}
+	}
+	broker.topics[topic][sub.channel] = sub
+	return sub.channel, nil
+}
+
+// Unsubscribe melepas subscriber dari topic dan menutup channel-nya
+func (broker *Broker[T]) Unsubscribe(topic string, channel <-chan T) {
+	broker.mutex.Lock()
+	sub, ok := broker.topics[topic][channel]
+	if ok {
+		broker.remove(sub)
+	}
+	broker.mutex.Unlock()
+	if ok {
+		sub.close()
+	}
+}
+
+// remove menghapus subscriber dari map, pemanggil harus memegang broker.mutex
+func (broker *Broker[T]) remove(sub *subscriber[T]) {
+	delete(broker.topics[sub.topic], sub.channel)
+	if len(broker.topics[sub.topic]) == 0 {
+		delete(broker.topics, sub.topic)
+	}
+}
+
+// Publish mengirim value ke semua subscriber dari topic sesuai policy masing-masing.
+// Dengan policy Block, Publish menunggu subscriber yang buffer-nya penuh
+func (broker *Broker[T]) Publish(topic string, value T) error {
+	broker.mutex.RLock()
+	if broker.closed {
+		broker.mutex.RUnlock()
+		return ErrClosed
+	}
+	subscribers := make([]*subscriber[T], 0, len(broker.topics[topic]))
+	for _, sub := range broker.topics[topic] {
+		subscribers = append(subscribers, sub)
+	}
+	broker.mutex.RUnlock()
+
+	for _, sub := range subscribers {
+		if !sub.send(value) {
+			// Subscriber lambat dengan policy Disconnect diputus
+			broker.mutex.Lock()
+			broker.remove(sub)
+			broker.mutex.Unlock()
+			sub.close()
+		}
+	}
+	return nil
+}
+
+// Stats mengembalikan statistik semua subscriber dari topic
+func (broker *Broker[T]) Stats(topic string) []Stats {
+	broker.mutex.RLock()
+	defer broker.mutex.RUnlock()
+	stats := make([]Stats, 0, len(broker.topics[topic]))
+	for _, sub := range broker.topics[topic] {
+		stats = append(stats, Stats{
+			Topic:     topic,
+			Lag:       len(sub.channel),
+			Capacity:  cap(sub.channel),
+			Delivered: sub.delivered.Load(),
+			Dropped:   sub.dropped.Load(),
+		})
+	}
+	return stats
+}
+
+// Close menutup broker dan semua channel subscriber. Publish yang sedang menunggu
+// ikut dibangunkan sehingga tidak ada goroutine yang tertinggal
+func (broker *Broker[T]) Close() {
+	broker.mutex.Lock()
+	if broker.closed {
+		broker.mutex.Unlock()
+		return
+	}
+	broker.closed = true
+	topics := broker.topics
+	broker.topics = map[string]map[<-chan T]*subscriber[T]{}
+	broker.mutex.Unlock()
+
+	for _, subscribers := range topics {
+		for _, sub := range subscribers {
+			sub.close()
+		}
+	}
+}
+
+// send mengirim value sesuai policy, mengembalikan false jika subscriber harus diputus
+func (sub *subscriber[T]) send(value T) bool {
+	sub.sendMutex.Lock()
+	defer sub.sendMutex.Unlock()
+	if sub.removed {
+		return true
+	}
+
+	select {
+	case sub.channel <- value:
+		sub.delivered.Add(1)
+		return true
+	default:
+	}
+
+	switch sub.policy {
+	case DropNewest:
+		sub.dropped.Add(1)
+	case DropOldest:
+		for {
+			select {
+			case sub.channel <- value:
+				sub.delivered.Add(1)
+				return true
+			default:
+			}
+			select {
+			case <-sub.channel:
+				sub.dropped.Add(1)
+			default:
+			}
+		}
+	case Disconnect:
+		sub.dropped.Add(1)
+		return false
+	default:
+		select {
+		case sub.channel <- value:
+			sub.delivered.Add(1)
+		case <-sub.done:
+		}
+	}
+	return true
+}
+
+// close membangunkan Publish yang sedang block, lalu menutup channel subscriber
+func (sub *subscriber[T]) close() {
+	sub.closeOnce.Do(func() {
+		close(sub.done)
+		sub.sendMutex.Lock()
+		sub.removed = true
+		close(sub.channel)
+		sub.sendMutex.Unlock()
+	})
+}
@@ -0,0 +1,158 @@
+package pubsub
+
+import (
+	"errors"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+)
+
+// TestPublishSubscribe memastikan setiap subscriber dari topic menerima semua pesan
+func TestPublishSubscribe(t *testing.T) {
+	broker := NewBroker[string](Block)
+	defer broker.Close()
+
+	channel1, _ := broker.Subscribe("berita", 0)
+	channel2, _ := broker.Subscribe("berita", 0)
+	other, _ := broker.Subscribe("lain", 1)
+
+	group := sync.WaitGroup{}
+	for _, channel := range []<-chan string{channel1, channel2} {
+		group.Add(1)
+		go func() {
+			defer group.Done()
+			for i := 0; i < 3; i++ {
+				if data := <-channel; data != "Aidil Adam Baik Hati" {
+					t.Errorf("data = %q", data)
+				}
+			}
+		}()
+	}
+	for i := 0; i < 3; i++ {
+		broker.Publish("berita", "Aidil Adam Baik Hati")
+	}
+	group.Wait()
+
+	select {
+	case data := <-other:
+		t.Fatalf("topic lain tidak boleh menerima %q", data)
+	default:
+	}
+}
+
+// TestPolicies memastikan setiap policy memperlakukan subscriber lambat sesuai definisinya
+func TestPolicies(t *testing.T) {
+	broker := NewBroker[int](Block)
+	defer broker.Close()
+
+	oldest, _ := broker.SubscribeWithPolicy("angka", 2, DropOldest)
+	newest, _ := broker.SubscribeWithPolicy("angka", 2, DropNewest)
+	disconnect, _ := broker.SubscribeWithPolicy("angka", 2, Disconnect)
+
+	for i := 1; i <= 4; i++ {
+		broker.Publish("angka", i)
+	}
+
+	if a, b := <-oldest, <-oldest; a != 3 || b != 4 {
+		t.Fatalf("DropOldest = %d %d, seharusnya 3 4", a, b)
+	}
+	if a, b := <-newest, <-newest; a != 1 || b != 2 {
+		t.Fatalf("DropNewest = %d %d, seharusnya 1 2", a, b)
+	}
+	<-disconnect
+	<-disconnect
+	if _, ok := <-disconnect; ok {
+		t.Fatal("subscriber Disconnect seharusnya sudah ditutup")
+	}
+
+	stats := broker.Stats("angka")
+	if len(stats) != 2 {
+		t.Fatalf("subscriber tersisa = %d, seharusnya 2", len(stats))
+	}
+	for _, stat := range stats {
+		if stat.Dropped != 2 {
+			t.Fatalf("stats = %+v", stat)
+		}
+	}
+}
+
+// TestDropOldestUnbuffered memastikan DropOldest tanpa buffer membuang pesan baru
+// dan tidak membuat Publish maupun Unsubscribe menunggu selamanya
+func TestDropOldestUnbuffered(t *testing.T) {
+	broker := NewBroker[int](DropOldest)
+	defer broker.Close()
+	channel, _ := broker.Subscribe("angka", 0)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		broker.Publish("angka", 1)
+		broker.Unsubscribe("angka", channel)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Publish ke subscriber DropOldest tanpa buffer tidak selesai")
+	}
+	if _, ok := <-channel; ok {
+		t.Fatal("channel seharusnya sudah ditutup tanpa pesan")
+	}
+}
+
+// TestLag memastikan lag subscriber sama dengan pesan yang belum dibaca
+func TestLag(t *testing.T) {
+	broker := NewBroker[int](DropNewest)
+	defer broker.Close()
+	channel, _ := broker.Subscribe("angka", 5)
+	for i := 0; i < 3; i++ {
+		broker.Publish("angka", i)
+	}
+	<-channel
+	if stats := broker.Stats("angka"); stats[0].Lag != 2 || stats[0].Delivered != 3 {
+		t.Fatalf("stats = %+v", stats[0])
+	}
+}
+
+// TestCloseNoLeak memastikan Close menutup channel subscriber dan membangunkan publisher
+// yang sedang block, sehingga jumlah goroutine kembali seperti semula
+func TestCloseNoLeak(t *testing.T) {
+	before := runtime.NumGoroutine()
+
+	broker := NewBroker[int](Block)
+	channel, _ := broker.Subscribe("angka", 0)
+	for i := 0; i < 10; i++ {
+		go broker.Publish("angka", i)
+	}
+	time.Sleep(10 * time.Millisecond)
+	broker.Close()
+
+	for range channel {
+		// Pesan yang sempat terkirim sebelum Close dibaca sampai channel ditutup
+	}
+	if err := broker.Publish("angka", 1); !errors.Is(err, ErrClosed) {
+		t.Fatalf("err = %v", err)
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for runtime.NumGoroutine() > before && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if after := runtime.NumGoroutine(); after > before {
+		t.Fatalf("goroutine bocor: sebelum %d, sesudah %d", before, after)
+	}
+}
+
+// TestUnsubscribe memastikan channel ditutup dan subscriber tidak menerima pesan lagi
+func TestUnsubscribe(t *testing.T) {
+	broker := NewBroker[int](Block)
+	defer broker.Close()
+	channel, _ := broker.Subscribe("angka", 1)
+	broker.Unsubscribe("angka", channel)
+	if _, ok := <-channel; ok {
+		t.Fatal("channel seharusnya ditutup")
+	}
+	if err := broker.Publish("angka", 1); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,142 @@
+package queue
+
+import (
+	"container/heap"
+	"context"
+	"sync"
+)
+
+// PriorityQueue adalah antrian tanpa batas kapasitas yang mengeluarkan item dengan prioritas
+// terbesar lebih dulu, dan FIFO untuk prioritas yang sama.
+// Dengan aging > 0, setiap aging item yang masuk setelah sebuah item menaikkan prioritas relatif
+// item tersebut sebesar 1, sehingga item berprioritas rendah tidak menunggu selamanya
+type PriorityQueue[T any] struct {
+	mutex    sync.Mutex
+	notEmpty *sync.Cond // Di-signal ketika item bertambah atau antrian ditutup
+
+	items  priorityHeap[T]
+	aging  int
+	seq    int64
+	closed bool
+}
+
+// priorityItem adalah item di heap beserta kunci urutannya
+type priorityItem[T any] struct {
+	value T
+	key   int64 // Prioritas yang sudah dikurangi umur item, semakin besar semakin dulu keluar
+	seq   int64 // Nomor urut masuk, untuk FIFO ketika key sama
+}
+
+// NewPriorityQueue membuat PriorityQueue. aging 0 berarti prioritas murni tanpa aging
+func NewPriorityQueue[T any](aging int) *PriorityQueue[T] {
+	queue := &PriorityQueue[T]{aging: aging}
+	queue.notEmpty = sync.NewCond(&queue.mutex)
+	return queue
+}
+
+// Push menambahkan item dengan prioritas priority tanpa menunggu.
+// Mengembalikan ErrClosed jika antrian sudah ditutup
+func (queue *PriorityQueue[T]) Push(item T, priority int) error {
+	queue.mutex.Lock()
+	defer queue.mutex.Unlock()
+	if queue.closed {
+		return ErrClosed
+	}
+
+	// Menaikkan prioritas semua item lama sama artinya dengan menurunkan prioritas item baru,
+	// sehingga kunci item cukup dihitung sekali ketika masuk
+	key := int64(priority)
+	if queue.aging > 0 {
+		key = int64(priority)*int64(queue.aging) - queue.seq
+	}
+	heap.Push(&queue.items, priorityItem[T]{value: item, key: key, seq: queue.seq})
+	queue.seq++
+	queue.notEmpty.Signal()
+	return nil
+}
+
+// Pop mengambil item dengan prioritas terbesar, menunggu selama antrian kosong.
+// Mengembalikan ErrClosed jika antrian sudah ditutup dan kosong
+func (queue *PriorityQueue[T]) Pop(ctx context.Context) (T, error) {
+	queue.mutex.Lock()
+	defer queue.mutex.Unlock()
+	for !queue.closed && len(queue.items) == 0 {
+		if err := queue.wait(ctx); err != nil {
+			var zero T
+			return zero, err
+		}
+	}
+	if len(queue.items) == 0 {
+		var zero T
+		return zero, ErrClosed
+	}
+	return heap.Pop(&queue.items).(priorityItem[T]).value, nil
+}
+
+// TryPop mengambil item dengan prioritas terbesar tanpa menunggu, mengembalikan false jika antrian kosong
+func (queue *PriorityQueue[T]) TryPop() (T, bool) {
+	queue.mutex.Lock()
+	defer queue.mutex.Unlock()
+	if len(queue.items) == 0 {
+		var zero T
+		return zero, false
+	}
+	return heap.Pop(&queue.items).(priorityItem[T]).value, true
+}
+
+// Close menutup antrian: Push berikutnya gagal, dan semua goroutine yang menunggu dibangunkan
+func (queue *PriorityQueue[T]) Close() {
+	queue.mutex.Lock()
+	defer queue.mutex.Unlock()
+	queue.closed = true
+	queue.notEmpty.Broadcast()
+}
+
+// Len mengembalikan jumlah item saat ini
+func (queue *PriorityQueue[T]) Len() int {
+	queue.mutex.Lock()
+	defer queue.mutex.Unlock()
+	return len(queue.items)
+}
+
+// wait menunggu notEmpty sampai dibangunkan atau ctx selesai, sama seperti Queue.wait
+func (queue *PriorityQueue[T]) wait(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	stop := context.AfterFunc(ctx, func() {
+		queue.mutex.Lock()
+		queue.notEmpty.Broadcast()
+		queue.mutex.Unlock()
+	})
+	queue.notEmpty.Wait()
+	stop()
+	if err := ctx.Err(); err != nil {
+		queue.notEmpty.Signal()
+		return err
+	}
+	return nil
+}
+
+// priorityHeap mengimplementasikan heap.Interface
+type priorityHeap[T any] []priorityItem[T]
+
+func (items priorityHeap[T]) Len() int { return len(items) }
+
+func (items priorityHeap[T]) Less(i, j int) bool {
+	if items[i].key != items[j].key {
+		return items[i].key > items[j].key
+	}
+	return items[i].seq < items[j].seq
+}
+
+func (items priorityHeap[T]) Swap(i, j int) { items[i], items[j] = items[j], items[i] }
+
+func (items *priorityHeap[T]) Push(item any) { *items = append(*items, item.(priorityItem[T])) }
+
+func (items *priorityHeap[T]) Pop() any {
+	old := *items
+	item := old[len(old)-1]
+	*items = old[:len(old)-1]
+	return item
+}
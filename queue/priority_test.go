@@ -0,0 +1,81 @@
+package queue
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// TestPriorityQueueOrder memastikan prioritas terbesar keluar lebih dulu dan FIFO untuk prioritas sama
+func TestPriorityQueueOrder(t *testing.T) {
+	queue := NewPriorityQueue[string](0)
+	queue.Push("low-1", 1)
+	queue.Push("high-1", 5)
+	queue.Push("low-2", 1)
+	queue.Push("high-2", 5)
+	queue.Push("mid", 3)
+
+	expected := []string{"high-1", "high-2", "mid", "low-1", "low-2"}
+	for _, want := range expected {
+		item, ok := queue.TryPop()
+		if !ok || item != want {
+			t.Fatalf("item = %q, seharusnya %q", item, want)
+		}
+	}
+	if _, ok := queue.TryPop(); ok {
+		t.Fatal("antrian seharusnya kosong")
+	}
+}
+
+// TestPriorityQueueAging memastikan item berprioritas rendah tetap keluar walaupun item
+// berprioritas tinggi terus ditambahkan setiap kali satu item diambil
+func TestPriorityQueueAging(t *testing.T) {
+	const aging = 3
+	queue := NewPriorityQueue[string](aging)
+	queue.Push("low", 1)
+
+	// Item low disusul paling banyak (5-1)*aging item high sebelum kuncinya sama
+	bound := (5 - 1) * aging
+	for i := 0; ; i++ {
+		queue.Push("high", 5)
+		item, _ := queue.TryPop()
+		if item == "low" {
+			break
+		}
+		if i > bound {
+			t.Fatalf("item low belum keluar setelah %d item high", i)
+		}
+	}
+}
+
+// TestPriorityQueuePop memastikan Pop menunggu item, menghormati context, dan berhenti setelah Close
+func TestPriorityQueuePop(t *testing.T) {
+	queue := NewPriorityQueue[int](0)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := queue.Pop(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("err = %v", err)
+	}
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		queue.Push(42, 1)
+	}()
+	if item, err := queue.Pop(context.Background()); err != nil || item != 42 {
+		t.Fatalf("item = %d, err = %v", item, err)
+	}
+
+	queue.Push(7, 1)
+	queue.Close()
+	if err := queue.Push(8, 1); !errors.Is(err, ErrClosed) {
+		t.Fatalf("Push setelah Close: err = %v", err)
+	}
+	if item, err := queue.Pop(context.Background()); err != nil || item != 7 {
+		t.Fatalf("item tersisa = %d, err = %v", item, err)
+	}
+	if _, err := queue.Pop(context.Background()); !errors.Is(err, ErrClosed) {
+		t.Fatalf("err = %v", err)
+	}
+}
@@ -0,0 +1,227 @@
+// Package queue berisi antrian blocking berbasis mutex dan sync.Cond. Berbeda dengan buffered
+// channel, kapasitasnya dapat diubah saat runtime, isinya dapat dikuras sekaligus, dan statistiknya
+// dapat dibaca
+package queue
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrClosed dikembalikan Put setelah Close, dan oleh Take ketika antrian sudah ditutup dan kosong
+var ErrClosed = errors.New("queue: closed")
+
+// Queue adalah antrian FIFO dengan kapasitas terbatas yang aman dipakai banyak goroutine.
+// Perilaku Close mengikuti channel: item yang tersisa tetap bisa diambil sampai antrian kosong
+type Queue[T any] struct {
+	mutex    sync.Mutex
+	notEmpty *sync.Cond // Di-signal ketika item bertambah atau antrian ditutup
+	notFull  *sync.Cond // Di-signal ketika item berkurang, kapasitas bertambah, atau antrian ditutup
+
+	items    []T // Ring buffer
+	head     int
+	count    int
+	capacity int
+	closed   bool
+
+	highWater int
+	puts      uint64
+	takes     uint64
+	started   time.Time
+}
+
+// Stats adalah statistik antrian
+type Stats struct {
+	Len           int     // Jumlah item saat ini
+	Capacity      int     // Kapasitas saat ini
+	HighWaterMark int     // Jumlah item terbanyak yang pernah berada di antrian
+	Puts          uint64  // Total item yang masuk
+	Takes         uint64  // Total item yang keluar
+	Throughput    float64 // Rata-rata item keluar per detik sejak antrian dibuat
+	Closed        bool    // true jika antrian sudah ditutup
+}
+
+// New membuat antrian dengan kapasitas capacity, minimal 1
+func New[T any](capacity int) *Queue[T] {
+	if capacity < 1 {
+		capacity = 1
+	}
+	queue := &Queue[T]{
+		items:    make([]T, capacity),
+		capacity: capacity,
+		started:  time.Now(),
+	}
+	queue.notEmpty = sync.NewCond(&queue.mutex)
+	queue.notFull = sync.NewCond(&queue.mutex)
+	return queue
+}
+
+// wait menunggu cond sampai dibangunkan atau ctx selesai. Pemanggil harus memegang mutex
+func (queue *Queue[T]) wait(ctx context.Context, cond *sync.Cond) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	// sync.Cond tidak mengenal context, sehingga pembatalan context membangunkan semua waiter
+	stop := context.AfterFunc(ctx, func() {
+		queue.mutex.Lock()
+		cond.Broadcast()
+		queue.mutex.Unlock()
+	})
+	cond.Wait()
+	stop()
+	if err := ctx.Err(); err != nil {
+		// Signal yang mungkin diterima goroutine ini diteruskan ke waiter lain agar tidak hilang
+		cond.Signal()
+		return err
+	}
+	return nil
+}
+
+// Put menambahkan item, menunggu selama antrian penuh
+func (queue *Queue[T]) Put(ctx context.Context, item T) error {
+	queue.mutex.Lock()
+	defer queue.mutex.Unlock()
+	for !queue.closed && queue.count >= queue.capacity {
+		if err := queue.wait(ctx, queue.notFull); err != nil {
+			return err
+		}
+	}
+	if queue.closed {
+		return ErrClosed
+	}
+	queue.push(item)
+	return nil
+}
+
+// TryPut menambahkan item tanpa menunggu, mengembalikan false jika antrian penuh atau sudah ditutup
+func (queue *Queue[T]) TryPut(item T) bool {
+	queue.mutex.Lock()
+	defer queue.mutex.Unlock()
+	if queue.closed || queue.count >= queue.capacity {
+		return false
+	}
+	queue.push(item)
+	return true
+}
+
+// Take mengambil item terdepan, menunggu selama antrian kosong.
+// Mengembalikan ErrClosed jika antrian sudah ditutup dan kosong
+func (queue *Queue[T]) Take(ctx context.Context) (T, error) {
+	queue.mutex.Lock()
+	defer queue.mutex.Unlock()
+	for !queue.closed && queue.count == 0 {
+		if err := queue.wait(ctx, queue.notEmpty); err != nil {
+			var zero T
+			return zero, err
+		}
+	}
+	if queue.count == 0 {
+		var zero T
+		return zero, ErrClosed
+	}
+	return queue.pop(), nil
+}
+
+// TryTake mengambil item terdepan tanpa menunggu, mengembalikan false jika antrian kosong
+func (queue *Queue[T]) TryTake() (T, bool) {
+	queue.mutex.Lock()
+	defer queue.mutex.Unlock()
+	if queue.count == 0 {
+		var zero T
+		return zero, false
+	}
+	return queue.pop(), true
+}
+
+// DrainTo memindahkan maksimal maxItems item (atau semua jika maxItems <= 0) ke dst tanpa menunggu
+// dan mengembalikan slice hasilnya
+func (queue *Queue[T]) DrainTo(dst []T, maxItems int) []T {
+	queue.mutex.Lock()
+	defer queue.mutex.Unlock()
+	n := queue.count
+	if maxItems > 0 && maxItems < n {
+		n = maxItems
+	}
+	for i := 0; i < n; i++ {
+		dst = append(dst, queue.pop())
+	}
+	return dst
+}
+
+// SetCapacity mengubah kapasitas antrian. Jika kapasitas baru lebih kecil dari jumlah item,
+// item tidak dibuang, tetapi Put menunggu sampai jumlah item turun di bawah kapasitas baru
+func (queue *Queue[T]) SetCapacity(capacity int) {
+	if capacity < 1 {
+		capacity = 1
+	}
+	queue.mutex.Lock()
+	defer queue.mutex.Unlock()
+
+	items := make([]T, max(capacity, queue.count))
+	for i := 0; i < queue.count; i++ {
+		items[i] = queue.items[(queue.head+i)%len(queue.items)]
+	}
+	queue.items = items
+	queue.head = 0
+	if capacity > queue.capacity {
+		queue.notFull.Broadcast()
+	}
+	queue.capacity = capacity
+}
+
+// Close menutup antrian: Put berikutnya gagal, dan semua goroutine yang menunggu dibangunkan
+func (queue *Queue[T]) Close() {
+	queue.mutex.Lock()
+	defer queue.mutex.Unlock()
+	queue.closed = true
+	queue.notEmpty.Broadcast()
+	queue.notFull.Broadcast()
+}
+
+// Len mengembalikan jumlah item saat ini
+func (queue *Queue[T]) Len() int {
+	queue.mutex.Lock()
+	defer queue.mutex.Unlock()
+	return queue.count
+}
+
+// Stats mengembalikan statistik antrian
+func (queue *Queue[T]) Stats() Stats {
+	queue.mutex.Lock()
+	defer queue.mutex.Unlock()
+	stats := Stats{
+		Len:           queue.count,
+		Capacity:      queue.capacity,
+		HighWaterMark: queue.highWater,
+		Puts:          queue.puts,
+		Takes:         queue.takes,
+		Closed:        queue.closed,
+	}
+	if elapsed := time.Since(queue.started).Seconds(); elapsed > 0 {
+		stats.Throughput = float64(queue.takes) / elapsed
+	}
+	return stats
+}
+
+// push menambahkan item ke belakang ring buffer, pemanggil harus memegang mutex
+func (queue *Queue[T]) push(item T) {
+	queue.items[(queue.head+queue.count)%len(queue.items)] = item
+	queue.count++
+	queue.puts++
+	queue.highWater = max(queue.highWater, queue.count)
+	queue.notEmpty.Signal()
+}
+
+// pop mengambil item dari depan ring buffer, pemanggil harus memegang mutex
+func (queue *Queue[T]) pop() T {
+	var zero T
+	item := queue.items[queue.head]
+	queue.items[queue.head] = zero
+	queue.head = (queue.head + 1) % len(queue.items)
+	queue.count--
+	queue.takes++
+	queue.notFull.Signal()
+	return item
+}
@@ -0,0 +1,133 @@
+package queue
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// TestPutTake menjalankan pola TestBufferedChannel: producer dan consumer berjalan bersamaan
+// pada antrian berkapasitas 3, dan semua item diterima sesuai urutan
+func TestPutTake(t *testing.T) {
+	queue := New[int](3)
+	ctx := context.Background()
+
+	go func() {
+		for i := 0; i < 100; i++ {
+			queue.Put(ctx, i)
+		}
+		queue.Close()
+	}()
+
+	expected := 0
+	for {
+		item, err := queue.Take(ctx)
+		if errors.Is(err, ErrClosed) {
+			break
+		}
+		if item != expected {
+			t.Fatalf("item = %d, seharusnya %d", item, expected)
+		}
+		expected++
+	}
+	if expected != 100 {
+		t.Fatalf("item diterima = %d", expected)
+	}
+
+	stats := queue.Stats()
+	if stats.HighWaterMark > 3 || stats.Puts != 100 || stats.Takes != 100 || !stats.Closed {
+		t.Fatalf("stats = %+v", stats)
+	}
+}
+
+// TestTryAndDrain memastikan TryPut/TryTake tidak menunggu dan DrainTo mengambil item sekaligus
+func TestTryAndDrain(t *testing.T) {
+	queue := New[string](3)
+	for _, name := range []string{"Aidil", "Adam", "Baik"} {
+		if !queue.TryPut(name) {
+			t.Fatalf("TryPut(%q) gagal", name)
+		}
+	}
+	if queue.TryPut("Hati") {
+		t.Fatal("TryPut seharusnya gagal ketika antrian penuh")
+	}
+
+	drained := queue.DrainTo(nil, 2)
+	if len(drained) != 2 || drained[0] != "Aidil" || drained[1] != "Adam" {
+		t.Fatalf("drained = %v", drained)
+	}
+	if item, ok := queue.TryTake(); !ok || item != "Baik" {
+		t.Fatalf("TryTake = %q %v", item, ok)
+	}
+	if _, ok := queue.TryTake(); ok {
+		t.Fatal("TryTake seharusnya gagal ketika antrian kosong")
+	}
+}
+
+// TestContext memastikan Put dan Take berhenti menunggu ketika context selesai
+func TestContext(t *testing.T) {
+	queue := New[int](1)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := queue.Take(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Take err = %v", err)
+	}
+
+	queue.TryPut(1)
+	ctx, cancel = context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := queue.Put(ctx, 2); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Put err = %v", err)
+	}
+}
+
+// TestSetCapacity memastikan producer yang menunggu dibangunkan ketika kapasitas diperbesar
+func TestSetCapacity(t *testing.T) {
+	queue := New[int](1)
+	queue.TryPut(1)
+
+	group := sync.WaitGroup{}
+	group.Add(1)
+	go func() {
+		defer group.Done()
+		queue.Put(context.Background(), 2)
+	}()
+	time.Sleep(10 * time.Millisecond)
+	queue.SetCapacity(2)
+	group.Wait()
+
+	if queue.Len() != 2 {
+		t.Fatalf("len = %d", queue.Len())
+	}
+	queue.SetCapacity(1)
+	if queue.TryPut(3) {
+		t.Fatal("TryPut seharusnya gagal setelah kapasitas diperkecil")
+	}
+	if drained := queue.DrainTo(nil, 0); len(drained) != 2 || drained[0] != 1 || drained[1] != 2 {
+		t.Fatalf("drained = %v", drained)
+	}
+}
+
+// TestCloseWakesWaiters memastikan Close membangunkan consumer yang sedang menunggu
+func TestCloseWakesWaiters(t *testing.T) {
+	queue := New[int](1)
+	result := make(chan error)
+	for i := 0; i < 3; i++ {
+		go func() {
+			_, err := queue.Take(context.Background())
+			result <- err
+		}()
+	}
+	time.Sleep(10 * time.Millisecond)
+	queue.Close()
+	for i := 0; i < 3; i++ {
+		if err := <-result; !errors.Is(err, ErrClosed) {
+			t.Fatalf("err = %v", err)
+		}
+	}
+	if err := queue.Put(context.Background(), 1); !errors.Is(err, ErrClosed) {
+		t.Fatalf("err = %v", err)
+	}
+}
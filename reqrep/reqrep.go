@@ -0,0 +1,195 @@
+// Package reqrep berisi pola request/reply di atas channel. Setiap request membawa
+// correlation ID, context, dan channel balasannya sendiri, sehingga banyak request
+// dapat berjalan bersamaan dan request yang ditinggalkan dapat dibatalkan
+package reqrep
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ErrClosed dikembalikan ketika Requester sudah ditutup
+var ErrClosed = errors.New("reqrep: requester closed")
+
+// ErrStopped dikembalikan ketika Serve sudah berhenti sebelum request dibalas
+var ErrStopped = errors.New("reqrep: responder stopped")
+
+// Reply adalah balasan untuk satu request
+type Reply[Resp any] struct {
+	ID    uint64 // Correlation ID yang sama dengan request
+	Value Resp   // Hasil dari handler
+	Err   error  // Error dari handler
+}
+
+// Request adalah satu permintaan yang diterima oleh Responder
+type Request[Req, Resp any] struct {
+	ID      uint64          // Correlation ID unik per Requester
+	Payload Req             // Isi permintaan
+	Context context.Context // Dibatalkan ketika pengirim berhenti menunggu balasan
+	reply   chan<- Reply[Resp]
+}
+
+// Reply mengirim balasan untuk request ini. Channel balasan ber-buffer 1 sehingga Reply tidak pernah
+// block, dan mengembalikan false jika pengirim sudah tidak menunggu atau balasan sudah pernah dikirim
+func (request Request[Req, Resp]) Reply(value Resp, err error) bool {
+	if request.Context.Err() != nil {
+		return false
+	}
+	select {
+	case request.reply <- Reply[Resp]{ID: request.ID, Value: value, Err: err}:
+		return true
+	default:
+		return false
+	}
+}
+
+// Requester mengirim request ke Responder dan menunggu balasannya
+type Requester[Req, Resp any] struct {
+	requests    chan Request[Req, Resp]
+	nextID      atomic.Uint64
+	outstanding atomic.Int64
+
+	mutex     sync.RWMutex  // Dipegang Request ketika mengirim, Close mengambil write lock sebelum menutup requests
+	done      chan struct{} // Ditutup oleh Close, membangunkan Request yang sedang menunggu giliran
+	closeOnce sync.Once
+	stopped   chan struct{} // Ditutup ketika Serve berhenti, membangunkan Request yang menunggu balasan
+	stopOnce  sync.Once
+}
+
+// Responder menerima request dari Requester pasangannya
+type Responder[Req, Resp any] struct {
+	requests  <-chan Request[Req, Resp]
+	requester *Requester[Req, Resp]
+}
+
+// New membuat pasangan Requester dan Responder yang terhubung dengan channel ber-buffer bufferSize
+func New[Req, Resp any](bufferSize int) (*Requester[Req, Resp], *Responder[Req, Resp]) {
+	requests := make(chan Request[Req, Resp], bufferSize)
+	requester := &Requester[Req, Resp]{requests: requests, done: make(chan struct{}), stopped: make(chan struct{})}
+	return requester, &Responder[Req, Resp]{requests: requests, requester: requester}
+}
+
+// Request mengirim payload dan menunggu balasan sampai ctx selesai.
+// Jika ctx selesai lebih dulu, context milik request ikut dibatalkan agar handler dapat berhenti.
+// Jika Serve berhenti sebelum membalas, Request gagal dengan ErrStopped walaupun ctx tidak punya batas waktu
+func (requester *Requester[Req, Resp]) Request(ctx context.Context, payload Req) (Resp, error) {
+	var zero Resp
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	reply := make(chan Reply[Resp], 1)
+	request := Request[Req, Resp]{
+		ID:      requester.nextID.Add(1),
+		Payload: payload,
+		Context: ctx,
+		reply:   reply,
+	}
+
+	requester.outstanding.Add(1)
+	defer requester.outstanding.Add(-1)
+
+	// Read lock mencegah Close menutup channel ketika request sedang dikirim. Close menutup done
+	// sebelum mengambil write lock, sehingga pengiriman yang sedang menunggu dilepas lebih dulu
+	requester.mutex.RLock()
+	select {
+	case <-requester.done:
+		requester.mutex.RUnlock()
+		return zero, ErrClosed
+	case <-requester.stopped:
+		requester.mutex.RUnlock()
+		return zero, ErrStopped
+	default:
+	}
+	select {
+	case requester.requests <- request:
+		requester.mutex.RUnlock()
+	case <-requester.done:
+		requester.mutex.RUnlock()
+		return zero, ErrClosed
+	case <-requester.stopped:
+		requester.mutex.RUnlock()
+		return zero, ErrStopped
+	case <-ctx.Done():
+		requester.mutex.RUnlock()
+		return zero, ctx.Err()
+	}
+
+	select {
+	case result := <-reply:
+		return result.Value, result.Err
+	case <-requester.stopped:
+		// Balasan yang dikirim tepat sebelum Serve berhenti tetap dipakai
+		select {
+		case result := <-reply:
+			return result.Value, result.Err
+		default:
+			return zero, ErrStopped
+		}
+	case <-ctx.Done():
+		return zero, ctx.Err()
+	}
+}
+
+// RequestTimeout sama seperti Request dengan batas waktu timeout
+func (requester *Requester[Req, Resp]) RequestTimeout(payload Req, timeout time.Duration) (Resp, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	return requester.Request(ctx, payload)
+}
+
+// Outstanding mengembalikan jumlah request yang sedang menunggu balasan
+func (requester *Requester[Req, Resp]) Outstanding() int {
+	return int(requester.outstanding.Load())
+}
+
+// Close menutup channel request sehingga Serve milik Responder berhenti setelah request tersisa diproses.
+// Request yang sedang menunggu giliran masuk ke channel langsung gagal dengan ErrClosed, sehingga Close
+// tidak ikut menunggu walaupun Responder sudah berhenti
+func (requester *Requester[Req, Resp]) Close() {
+	requester.closeOnce.Do(func() {
+		close(requester.done)
+		requester.mutex.Lock()
+		defer requester.mutex.Unlock()
+		close(requester.requests)
+	})
+}
+
+// Requests mengembalikan channel read-only berisi request untuk diproses secara manual
+func (responder *Responder[Req, Resp]) Requests() <-chan Request[Req, Resp] {
+	return responder.requests
+}
+
+// Serve menjalankan handler pada workers goroutine sampai Requester ditutup atau ctx selesai.
+// Request yang pengirimnya sudah berhenti menunggu dilewati tanpa memanggil handler.
+// Setelah Serve kembali, request yang belum dibalas dan request berikutnya gagal dengan ErrStopped
+func (responder *Responder[Req, Resp]) Serve(ctx context.Context, workers int, handler func(ctx context.Context, payload Req) (Resp, error)) {
+	requester := responder.requester
+	defer requester.stopOnce.Do(func() { close(requester.stopped) })
+
+	group := sync.WaitGroup{}
+	for i := 0; i < workers; i++ {
+		group.Add(1)
+		go func() {
+			defer group.Done()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case request, ok := <-responder.requests:
+					if !ok {
+						return
+					}
+					if request.Context.Err() != nil {
+						continue
+					}
+					value, err := handler(request.Context, request.Payload)
+					request.Reply(value, err)
+				}
+			}
+		}()
+	}
+	group.Wait()
+}
@@ -0,0 +1,175 @@
+package reqrep
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestConcurrentRequests memastikan setiap balasan kembali ke request yang benar
+// walaupun banyak request berjalan bersamaan dan diproses tidak berurutan
+func TestConcurrentRequests(t *testing.T) {
+	requester, responder := New[int, string](0)
+	go responder.Serve(context.Background(), 4, func(ctx context.Context, payload int) (string, error) {
+		time.Sleep(time.Duration(payload%5) * time.Millisecond)
+		return "Balasan " + strconv.Itoa(payload), nil
+	})
+	defer requester.Close()
+
+	group := sync.WaitGroup{}
+	for i := 0; i < 100; i++ {
+		group.Add(1)
+		go func() {
+			defer group.Done()
+			reply, err := requester.RequestTimeout(i, time.Second)
+			if err != nil || reply != "Balasan "+strconv.Itoa(i) {
+				t.Errorf("request %d: reply=%q err=%v", i, reply, err)
+			}
+		}()
+	}
+	group.Wait()
+
+	if requester.Outstanding() != 0 {
+		t.Fatalf("outstanding = %d", requester.Outstanding())
+	}
+}
+
+// TestTimeoutCancelsHandler memastikan request yang timeout membatalkan context milik handler
+func TestTimeoutCancelsHandler(t *testing.T) {
+	requester, responder := New[string, string](0)
+	canceled := make(chan struct{})
+	go responder.Serve(context.Background(), 1, func(ctx context.Context, payload string) (string, error) {
+		<-ctx.Done()
+		close(canceled)
+		return "", ctx.Err()
+	})
+	defer requester.Close()
+
+	_, err := requester.RequestTimeout("lambat", 20*time.Millisecond)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("err = %v", err)
+	}
+	select {
+	case <-canceled:
+	case <-time.After(time.Second):
+		t.Fatal("handler tidak menerima pembatalan")
+	}
+}
+
+// TestAbandonedRequestSkipped memastikan request yang sudah ditinggalkan tidak diproses handler
+func TestAbandonedRequestSkipped(t *testing.T) {
+	requester, responder := New[int, int](1)
+	defer requester.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	result := make(chan error)
+	go func() {
+		_, err := requester.Request(ctx, 1)
+		result <- err
+	}()
+	request := <-responder.Requests()
+	cancel()
+	if err := <-result; !errors.Is(err, context.Canceled) {
+		t.Fatalf("err = %v", err)
+	}
+	if request.Reply(2, nil) {
+		t.Fatal("Reply seharusnya false karena pengirim sudah berhenti menunggu")
+	}
+
+	var handled atomic.Int64
+	ctx, cancel = context.WithCancel(context.Background())
+	cancel()
+	requester.Request(ctx, 3)
+	requester.Close()
+	responder.Serve(context.Background(), 1, func(ctx context.Context, payload int) (int, error) {
+		handled.Add(1)
+		return payload, nil
+	})
+	if handled.Load() != 0 {
+		t.Fatalf("handler dipanggil %d kali untuk request yang dibatalkan", handled.Load())
+	}
+}
+
+// TestClosed memastikan request setelah Close langsung gagal
+func TestClosed(t *testing.T) {
+	requester, _ := New[int, int](0)
+	requester.Close()
+	requester.Close()
+	if _, err := requester.RequestTimeout(1, time.Second); !errors.Is(err, ErrClosed) {
+		t.Fatalf("err = %v", err)
+	}
+}
+
+// TestCloseWhileSending memastikan Close tidak menunggu Request tanpa batas waktu yang masih
+// menunggu giliran masuk ke channel ketika tidak ada Responder yang membaca
+func TestCloseWhileSending(t *testing.T) {
+	requester, _ := New[int, int](0)
+	result := make(chan error, 1)
+	go func() {
+		_, err := requester.Request(context.Background(), 1)
+		result <- err
+	}()
+	for requester.Outstanding() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	closed := make(chan struct{})
+	go func() {
+		requester.Close()
+		close(closed)
+	}()
+	select {
+	case <-closed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Close tidak selesai")
+	}
+	if err := <-result; !errors.Is(err, ErrClosed) {
+		t.Fatalf("err = %v", err)
+	}
+}
+
+// TestServeStopped memastikan Request tanpa batas waktu tidak menunggu selamanya ketika Serve
+// berhenti karena ctx selesai tanpa Close, baik yang sedang diproses maupun yang masih di buffer
+func TestServeStopped(t *testing.T) {
+	requester, responder := New[int, int](1)
+	ctx, cancel := context.WithCancel(context.Background())
+	served := make(chan struct{})
+	go func() {
+		defer close(served)
+		responder.Serve(ctx, 1, func(_ context.Context, payload int) (int, error) {
+			<-ctx.Done()
+			return 0, ctx.Err()
+		})
+	}()
+
+	results := make(chan error, 2)
+	for i := 0; i < 2; i++ {
+		go func() {
+			_, err := requester.Request(context.Background(), i)
+			results <- err
+		}()
+	}
+	for requester.Outstanding() < 2 || len(responder.Requests()) < 1 {
+		time.Sleep(time.Millisecond)
+	}
+	cancel()
+	<-served
+
+	for i := 0; i < 2; i++ {
+		select {
+		case err := <-results:
+			if !errors.Is(err, context.Canceled) && !errors.Is(err, ErrStopped) {
+				t.Fatalf("err = %v", err)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("Request masih menunggu setelah Serve berhenti")
+		}
+	}
+	if _, err := requester.Request(context.Background(), 3); !errors.Is(err, ErrStopped) {
+		t.Fatalf("err = %v, seharusnya ErrStopped", err)
+	}
+}
@@ -0,0 +1,6 @@
+//go:build race
+
+package scenario
+
+// raceEnabled bernilai true jika test dijalankan dengan -race
+const raceEnabled = true
@@ -0,0 +1,110 @@
+// Package scenario berisi versi terparameterisasi dari demo di package utama (race condition,
+// mutex, deadlock, cond, pool, dan seterusnya). Setiap skenario menunggu goroutine-nya selesai
+// dengan WaitGroup atau channel, bukan time.Sleep, dan mengembalikan hasil yang dapat dibandingkan
+package scenario
+
+import (
+	"belajar-golang-goroutines/monitor"
+	"context"
+	"io"
+	"sort"
+	"time"
+)
+
+// Config adalah parameter skenario. Field bernilai 0 diganti dengan default milik skenario
+type Config struct {
+	Goroutines int              // Jumlah goroutine yang dijalankan
+	Iterations int              // Jumlah perulangan di setiap goroutine
+	Delay      time.Duration    // Lama simulasi proses, interval ticker, atau durasi timer
+	Timeout    time.Duration    // Batas waktu skenario yang bisa macet, misalnya deadlock
+	Procs      int              // Nilai GOMAXPROCS selama skenario berjalan, 0 berarti tidak diubah
+	Log        io.Writer        // Tujuan log kejadian seperti "Lock user1", nil berarti dibuang
+	Monitor    *monitor.Monitor // Jika diisi, lock dan channel milik skenario didaftarkan ke monitor
+}
+
+// Result adalah hasil satu skenario
+type Result struct {
+	Scenario   string           `json:"scenario"`
+	Goroutines int              `json:"goroutines"`
+	Iterations int              `json:"iterations"`
+	Delay      time.Duration    `json:"delay_ns"`
+	Procs      int              `json:"gomaxprocs"`
+	Elapsed    time.Duration    `json:"elapsed_ns"`
+	Metrics    map[string]int64 `json:"metrics"`
+}
+
+// MetricNames mengembalikan nama metric secara terurut, berguna untuk output teks
+func (result Result) MetricNames() []string {
+	names := make([]string, 0, len(result.Metrics))
+	for name := range result.Metrics {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Scenario adalah satu demo yang dapat dijalankan dengan parameter berbeda
+type Scenario struct {
+	Name        string
+	Description string
+	Defaults    Config
+	run         func(ctx context.Context, config Config, metrics map[string]int64) error
+}
+
+// Run menjalankan skenario dengan config yang field kosongnya diisi dari Defaults
+func (scenario Scenario) Run(ctx context.Context, config Config) (Result, error) {
+	config = scenario.withDefaults(config)
+	if config.Log == nil {
+		config.Log = io.Discard
+	}
+
+	result := Result{
+		Scenario:   scenario.Name,
+		Goroutines: config.Goroutines,
+		Iterations: config.Iterations,
+		Delay:      config.Delay,
+		Metrics:    make(map[string]int64),
+	}
+	restore := setProcs(config.Procs)
+	defer restore()
+	result.Procs = currentProcs()
+
+	start := time.Now()
+	err := scenario.run(ctx, config, result.Metrics)
+	result.Elapsed = time.Since(start)
+	return result, err
+}
+
+func (scenario Scenario) withDefaults(config Config) Config {
+	if config.Goroutines <= 0 {
+		config.Goroutines = scenario.Defaults.Goroutines
+	}
+	if config.Iterations <= 0 {
+		config.Iterations = scenario.Defaults.Iterations
+	}
+	if config.Delay <= 0 {
+		config.Delay = scenario.Defaults.Delay
+	}
+	if config.Timeout <= 0 {
+		config.Timeout = scenario.Defaults.Timeout
+	}
+	return config
+}
+
+// All mengembalikan semua skenario, terurut berdasarkan nama
+func All() []Scenario {
+	all := make([]Scenario, len(registry))
+	copy(all, registry)
+	sort.Slice(all, func(i, j int) bool { return all[i].Name < all[j].Name })
+	return all
+}
+
+// Lookup mencari skenario berdasarkan nama
+func Lookup(name string) (Scenario, bool) {
+	for _, scenario := range registry {
+		if scenario.Name == name {
+			return scenario, true
+		}
+	}
+	return Scenario{}, false
+}
@@ -0,0 +1,122 @@
+package scenario
+
+import (
+	"belajar-golang-goroutines/monitor"
+	"context"
+	"testing"
+	"time"
+)
+
+// run menjalankan skenario berdasarkan nama dan menghentikan test jika gagal
+func run(t *testing.T, name string, config Config) Result {
+	t.Helper()
+	scenario, ok := Lookup(name)
+	if !ok {
+		t.Fatalf("skenario %q tidak ditemukan", name)
+	}
+	result, err := scenario.Run(context.Background(), config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Scenario != name || result.Metrics == nil {
+		t.Fatalf("result = %+v", result)
+	}
+	return result
+}
+
+// TestAllRegistered memastikan setiap subcommand cmd/goroutines punya skenario
+func TestAllRegistered(t *testing.T) {
+	names := []string{"bank-actor", "bank-backoff", "bank-global", "bank-ordered", "cond", "deadlock", "gomaxprocs", "goroutine", "map", "mutex", "once", "pool", "race", "rwmutex", "ticker", "timer"}
+	all := All()
+	if len(all) != len(names) {
+		t.Fatalf("jumlah skenario = %d", len(all))
+	}
+	for i, scenario := range all {
+		if scenario.Name != names[i] || scenario.Description == "" {
+			t.Fatalf("skenario %d = %q", i, scenario.Name)
+		}
+	}
+}
+
+// TestRace memastikan metric race konsisten. Tidak dijalankan dengan -race karena
+// skenario ini memang sengaja membuat data race
+func TestRace(t *testing.T) {
+	if raceEnabled {
+		t.Skip("skenario race sengaja membuat data race")
+	}
+	result := run(t, "race", Config{Goroutines: 10, Iterations: 1000})
+	if result.Metrics["expected"] != 10000 || result.Metrics["actual"]+result.Metrics["lost"] != 10000 {
+		t.Fatalf("metrics = %v", result.Metrics)
+	}
+}
+
+// TestCounters memastikan skenario yang memakai sinkronisasi tidak kehilangan update
+func TestCounters(t *testing.T) {
+	if result := run(t, "mutex", Config{Goroutines: 10, Iterations: 100}); result.Metrics["lost"] != 0 || result.Metrics["actual"] != 1000 {
+		t.Fatalf("mutex = %v", result.Metrics)
+	}
+	if result := run(t, "rwmutex", Config{Goroutines: 10, Iterations: 100}); result.Metrics["balance"] != 1000 {
+		t.Fatalf("rwmutex = %v", result.Metrics)
+	}
+	if result := run(t, "once", Config{Goroutines: 50}); result.Metrics["executed"] != 1 || result.Metrics["calls"] != 50 {
+		t.Fatalf("once = %v", result.Metrics)
+	}
+	if result := run(t, "map", Config{Goroutines: 10, Iterations: 10}); result.Metrics["entries"] != 100 {
+		t.Fatalf("map = %v", result.Metrics)
+	}
+	if result := run(t, "pool", Config{Goroutines: 4, Iterations: 10}); result.Metrics["gets"] != 40 {
+		t.Fatalf("pool = %v", result.Metrics)
+	}
+	if result := run(t, "bank-ordered", Config{Goroutines: 4, Iterations: 3, Delay: 20 * time.Millisecond}); result.Metrics["violations"] != 0 || result.Metrics["operations"] == 0 {
+		t.Fatalf("bank-ordered = %v", result.Metrics)
+	}
+	if result := run(t, "goroutine", Config{Goroutines: 50, Iterations: 2, Delay: time.Microsecond}); result.Metrics["received"] != 100 {
+		t.Fatalf("goroutine = %v", result.Metrics)
+	}
+}
+
+// TestDeadlock memastikan deadlock terdeteksi ketika kedua transfer sempat mengunci
+// rekening pertamanya sebelum timeout
+func TestDeadlock(t *testing.T) {
+	watcher := monitor.New()
+	result := run(t, "deadlock", Config{Delay: 50 * time.Millisecond, Timeout: 200 * time.Millisecond, Monitor: watcher})
+	if result.Metrics["deadlocked"] != 1 {
+		t.Fatalf("metrics = %v", result.Metrics)
+	}
+
+	// Kedua rekening tetap terkunci dan saling menunggu setelah skenario selesai
+	snapshot := watcher.Sample()
+	if len(snapshot.Locks) != 2 || len(snapshot.Channels) != 1 {
+		t.Fatalf("snapshot = %+v", snapshot)
+	}
+	for _, lock := range snapshot.Locks {
+		if !lock.Held || lock.Waiters != 1 {
+			t.Fatalf("lock = %+v", lock)
+		}
+	}
+}
+
+// TestTiming memastikan skenario berbasis waktu menghasilkan jumlah kejadian yang benar
+func TestTiming(t *testing.T) {
+	if result := run(t, "cond", Config{Goroutines: 5, Delay: time.Millisecond}); result.Metrics["woken"] != 5 {
+		t.Fatalf("cond = %v", result.Metrics)
+	}
+	if result := run(t, "ticker", Config{Iterations: 3, Delay: 5 * time.Millisecond}); result.Metrics["ticks"] != 3 {
+		t.Fatalf("ticker = %v", result.Metrics)
+	}
+	if result := run(t, "timer", Config{Goroutines: 5, Delay: 5 * time.Millisecond}); result.Metrics["fired"] != 5 {
+		t.Fatalf("timer = %v", result.Metrics)
+	}
+}
+
+// TestGomaxprocs memastikan Procs mengubah GOMAXPROCS selama skenario dan memulihkannya setelahnya
+func TestGomaxprocs(t *testing.T) {
+	before := currentProcs()
+	result := run(t, "gomaxprocs", Config{Goroutines: 10, Delay: time.Millisecond, Procs: 3})
+	if result.Procs != 3 || result.Metrics["gomaxprocs"] != 3 || result.Metrics["goroutines"] < 10 {
+		t.Fatalf("result = %+v", result)
+	}
+	if currentProcs() != before {
+		t.Fatalf("GOMAXPROCS = %d, seharusnya dipulihkan ke %d", currentProcs(), before)
+	}
+}
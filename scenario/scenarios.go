@@ -0,0 +1,533 @@
+package scenario
+
+import (
+	"belajar-golang-goroutines/bank"
+	"belajar-golang-goroutines/demo"
+	"belajar-golang-goroutines/monitor"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// registry berisi semua skenario, satu untuk setiap subcommand cmd/goroutines
+var registry = []Scenario{
+	{
+		Name:        "goroutine",
+		Description: "menjalankan banyak goroutine yang mengirim hasil ke buffered channel seperti TestManyGoroutine",
+		Defaults:    Config{Goroutines: 1000, Iterations: 1, Delay: time.Millisecond},
+		run:         runGoroutine,
+	},
+	{
+		Name:        "race",
+		Description: "menambah counter tanpa sinkronisasi seperti TestRaceCondition, metric lost menunjukkan update yang hilang",
+		Defaults:    Config{Goroutines: 1000, Iterations: 100},
+		run:         runRace,
+	},
+	{
+		Name:        "mutex",
+		Description: "menambah counter dengan sync.Mutex seperti TestMutex",
+		Defaults:    Config{Goroutines: 1000, Iterations: 100},
+		run:         runMutex,
+	},
+	{
+		Name:        "rwmutex",
+		Description: "menambah dan membaca saldo bank.Account seperti TestRWMutex",
+		Defaults:    Config{Goroutines: 100, Iterations: 100},
+		run:         runRWMutex,
+	},
+	{
+		Name:        "deadlock",
+		Description: "dua bank.Transfer berlawanan arah seperti TestDeadlock, metric deadlocked bernilai 1 jika macet sampai timeout",
+		Defaults:    Config{Goroutines: 2, Iterations: 1, Delay: 100 * time.Millisecond, Timeout: time.Second},
+		run:         runDeadlock,
+	},
+	{
+		Name:        "bank-global",
+		Description: "simulasi bank.Simulate dengan satu lock global, Iterations adalah jumlah rekening dan Delay lama simulasi",
+		Defaults:    Config{Goroutines: 8, Iterations: 10, Delay: time.Second},
+		run:         runBank("global"),
+	},
+	{
+		Name:        "bank-ordered",
+		Description: "simulasi bank.Simulate dengan lock per rekening yang dikunci berurutan, Iterations adalah jumlah rekening dan Delay lama simulasi",
+		Defaults:    Config{Goroutines: 8, Iterations: 10, Delay: time.Second},
+		run:         runBank("ordered"),
+	},
+	{
+		Name:        "bank-backoff",
+		Description: "simulasi bank.Simulate dengan try-lock dengan backoff acak, Iterations adalah jumlah rekening dan Delay lama simulasi",
+		Defaults:    Config{Goroutines: 8, Iterations: 10, Delay: time.Second},
+		run:         runBank("backoff"),
+	},
+	{
+		Name:        "bank-actor",
+		Description: "simulasi bank.Simulate dengan actor per rekening dengan transfer dua fase, Iterations adalah jumlah rekening dan Delay lama simulasi",
+		Defaults:    Config{Goroutines: 8, Iterations: 10, Delay: time.Second},
+		run:         runBank("actor"),
+	},
+	{
+		Name:        "cond",
+		Description: "membangunkan goroutine satu per satu dengan sync.Cond seperti TestCond",
+		Defaults:    Config{Goroutines: 10, Iterations: 1, Delay: 10 * time.Millisecond},
+		run:         runCond,
+	},
+	{
+		Name:        "pool",
+		Description: "meminjam dan mengembalikan objek sync.Pool seperti TestPool, metric allocations menunjukkan pemanggilan New",
+		Defaults:    Config{Goroutines: 10, Iterations: 100, Delay: time.Microsecond},
+		run:         runPool,
+	},
+	{
+		Name:        "once",
+		Description: "memanggil sync.Once dari banyak goroutine seperti TestOnce",
+		Defaults:    Config{Goroutines: 100, Iterations: 1},
+		run:         runOnce,
+	},
+	{
+		Name:        "map",
+		Description: "menyimpan data ke sync.Map dengan demo.AddToMap seperti TestMap",
+		Defaults:    Config{Goroutines: 100, Iterations: 1},
+		run:         runMap,
+	},
+	{
+		Name:        "ticker",
+		Description: "menerima tick dari time.Ticker seperti TestTicker, metric max_jitter_ns adalah selisih terbesar dari interval",
+		Defaults:    Config{Goroutines: 1, Iterations: 5, Delay: 100 * time.Millisecond},
+		run:         runTicker,
+	},
+	{
+		Name:        "timer",
+		Description: "menjalankan time.AfterFunc dari banyak goroutine seperti TestAfterFunc, metric max_late_ns adalah keterlambatan terbesar",
+		Defaults:    Config{Goroutines: 10, Iterations: 1, Delay: 100 * time.Millisecond},
+		run:         runTimer,
+	},
+	{
+		Name:        "gomaxprocs",
+		Description: "mencatat jumlah CPU, GOMAXPROCS, dan goroutine seperti TestGetGomaxprocs",
+		Defaults:    Config{Goroutines: 100, Iterations: 1, Delay: 100 * time.Millisecond},
+		run:         runGomaxprocs,
+	},
+}
+
+// setProcs mengubah GOMAXPROCS jika procs > 0 dan mengembalikan fungsi untuk memulihkannya
+func setProcs(procs int) func() {
+	if procs <= 0 {
+		return func() {}
+	}
+	previous := runtime.GOMAXPROCS(procs)
+	return func() { runtime.GOMAXPROCS(previous) }
+}
+
+func currentProcs() int { return runtime.GOMAXPROCS(-1) }
+
+// increment menambah counter tanpa sinkronisasi. Dipisah ke fungsi sendiri agar compiler
+// tidak menggabungkan perulangan menjadi satu penjumlahan dan update yang hilang tetap terlihat
+//
+//go:noinline
+func increment(counter *int) {
+	*counter = *counter + 1
+}
+
+// runBank menjalankan bank.Simulate dengan strategy bernama name. Pelanggaran invariant
+// dilaporkan lewat metric violations, bukan error, agar hasilnya tetap dapat dibandingkan
+func runBank(name string) func(ctx context.Context, config Config, metrics map[string]int64) error {
+	return func(ctx context.Context, config Config, metrics map[string]int64) error {
+		simulation := bank.SimulationConfig{Accounts: config.Iterations, Workers: config.Goroutines, Duration: config.Delay}
+		report, err := bank.Simulate(ctx, simulation, bank.Strategies[name])
+		if err != nil && !errors.Is(err, bank.ErrInvariant) {
+			return err
+		}
+		if config.Log != io.Discard {
+			report.Print(config.Log)
+		}
+
+		metrics["operations"] = report.Operations
+		metrics["transfers"] = report.Transfers
+		metrics["rejected"] = report.Rejected
+		metrics["throughput_per_sec"] = int64(report.Throughput)
+		metrics["p50_ns"] = int64(report.P50)
+		metrics["p99_ns"] = int64(report.P99)
+		metrics["max_ns"] = int64(report.Max)
+		metrics["checks"] = int64(report.Checks)
+		metrics["violations"] = int64(len(report.Violations))
+		return nil
+	}
+}
+
+// runGoroutine menjalankan Goroutines goroutine yang masing-masing mengirim Iterations angka ke
+// channel berkapasitas 100, sementara satu consumer membacanya dengan jeda Delay per angka
+func runGoroutine(ctx context.Context, config Config, metrics map[string]int64) error {
+	results := make(chan int, 100)
+	if config.Monitor != nil {
+		monitor.WatchChan(config.Monitor, "results", results)
+	}
+
+	group := sync.WaitGroup{}
+	for i := 0; i < config.Goroutines; i++ {
+		group.Add(1)
+		go func(number int) {
+			defer group.Done()
+			for j := 0; j < config.Iterations; j++ {
+				demo.DisplayNumber(config.Log, number)
+				select {
+				case results <- number:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}(i)
+	}
+	go func() {
+		group.Wait()
+		close(results)
+	}()
+
+	received := int64(0)
+	for range results {
+		received++
+		select {
+		case <-time.After(config.Delay):
+		case <-ctx.Done():
+		}
+	}
+
+	metrics["received"] = received
+	return ctx.Err()
+}
+
+func runRace(ctx context.Context, config Config, metrics map[string]int64) error {
+	x := 0
+	group := sync.WaitGroup{}
+	for i := 0; i < config.Goroutines; i++ {
+		group.Add(1)
+		go func() {
+			defer group.Done()
+			for j := 0; j < config.Iterations; j++ {
+				increment(&x)
+			}
+		}()
+	}
+	group.Wait()
+
+	expected := int64(config.Goroutines * config.Iterations)
+	metrics["expected"] = expected
+	metrics["actual"] = int64(x)
+	metrics["lost"] = expected - int64(x)
+	return nil
+}
+
+func runMutex(ctx context.Context, config Config, metrics map[string]int64) error {
+	x := 0
+	var mutex sync.Mutex
+	group := sync.WaitGroup{}
+	for i := 0; i < config.Goroutines; i++ {
+		group.Add(1)
+		go func() {
+			defer group.Done()
+			for j := 0; j < config.Iterations; j++ {
+				mutex.Lock()
+				x = x + 1
+				mutex.Unlock()
+			}
+		}()
+	}
+	group.Wait()
+
+	expected := int64(config.Goroutines * config.Iterations)
+	metrics["expected"] = expected
+	metrics["actual"] = int64(x)
+	metrics["lost"] = expected - int64(x)
+	return nil
+}
+
+func runRWMutex(ctx context.Context, config Config, metrics map[string]int64) error {
+	account := &bank.InstrumentedAccount{}
+	account.RWMutex.Name = "account"
+	if config.Monitor != nil {
+		config.Monitor.WatchLock(&account.RWMutex)
+	}
+	var reads atomic.Int64
+	group := sync.WaitGroup{}
+	for i := 0; i < config.Goroutines; i++ {
+		group.Add(1)
+		go func() {
+			defer group.Done()
+			for j := 0; j < config.Iterations; j++ {
+				account.AddBalance(1)
+				fmt.Fprintln(config.Log, account.GetBalance())
+				reads.Add(1)
+			}
+		}()
+	}
+	group.Wait()
+
+	metrics["expected"] = int64(config.Goroutines * config.Iterations)
+	metrics["balance"] = int64(account.GetBalance())
+	metrics["reads"] = reads.Load()
+	return nil
+}
+
+// runDeadlock menjalankan dua transfer berlawanan arah. Jika deadlock terjadi, kedua goroutine
+// tetap macet setelah skenario selesai karena sync.Mutex tidak bisa dibatalkan
+func runDeadlock(ctx context.Context, config Config, metrics map[string]int64) error {
+	user1 := bank.NewInstrumentedUserBalance("Aidil", 1000000)
+	user2 := bank.NewInstrumentedUserBalance("Budi", 1000000)
+	done := make(chan struct{}, 2)
+	if config.Monitor != nil {
+		config.Monitor.WatchLock(user1, user2)
+		monitor.WatchChan(config.Monitor, "done", done)
+	}
+
+	go func() {
+		bank.TransferContext(ctx, config.Log, user1, user2, 100000, config.Delay)
+		done <- struct{}{}
+	}()
+	go func() {
+		bank.TransferContext(ctx, config.Log, user2, user1, 200000, config.Delay)
+		done <- struct{}{}
+	}()
+
+	timeout := time.NewTimer(config.Timeout)
+	defer timeout.Stop()
+	completed := int64(0)
+	for completed < 2 {
+		select {
+		case <-done:
+			completed++
+		case <-timeout.C:
+			metrics["completed"] = completed
+			metrics["deadlocked"] = 1
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	metrics["completed"] = completed
+	metrics["deadlocked"] = 0
+	metrics["balance1"] = int64(user1.Balance)
+	metrics["balance2"] = int64(user2.Balance)
+	return nil
+}
+
+// runCond membangunkan goroutine satu per satu dengan Signal. Berbeda dengan TestCond,
+// setiap sinyal dicatat sebagai token sehingga sinyal yang dikirim sebelum goroutine
+// mulai menunggu tidak hilang
+func runCond(ctx context.Context, config Config, metrics map[string]int64) error {
+	locker := sync.Mutex{}
+	cond := sync.NewCond(&locker)
+	tokens := 0
+	var woken atomic.Int64
+
+	group := sync.WaitGroup{}
+	for i := 0; i < config.Goroutines; i++ {
+		group.Add(1)
+		go func(value int) {
+			defer group.Done()
+			cond.L.Lock()
+			for tokens == 0 {
+				cond.Wait()
+			}
+			tokens--
+			cond.L.Unlock()
+			woken.Add(1)
+			fmt.Fprintln(config.Log, "Done", value)
+		}(i)
+	}
+
+	for i := 0; i < config.Goroutines; i++ {
+		select {
+		case <-time.After(config.Delay):
+		case <-ctx.Done():
+			// Membangunkan semua goroutine agar tidak ada yang tertinggal
+			cond.L.Lock()
+			tokens += config.Goroutines
+			cond.Broadcast()
+			cond.L.Unlock()
+			group.Wait()
+			return ctx.Err()
+		}
+		cond.L.Lock()
+		tokens++
+		cond.Signal()
+		cond.L.Unlock()
+	}
+	group.Wait()
+
+	metrics["woken"] = woken.Load()
+	metrics["signals"] = int64(config.Goroutines)
+	return nil
+}
+
+func runPool(ctx context.Context, config Config, metrics map[string]int64) error {
+	var allocations, gets atomic.Int64
+	pool := sync.Pool{
+		New: func() any {
+			allocations.Add(1)
+			return new([]byte)
+		},
+	}
+
+	group := sync.WaitGroup{}
+	for i := 0; i < config.Goroutines; i++ {
+		group.Add(1)
+		go func() {
+			defer group.Done()
+			for j := 0; j < config.Iterations; j++ {
+				data := pool.Get()
+				gets.Add(1)
+				time.Sleep(config.Delay)
+				pool.Put(data)
+			}
+		}()
+	}
+	group.Wait()
+
+	metrics["gets"] = gets.Load()
+	metrics["allocations"] = allocations.Load()
+	metrics["reused"] = gets.Load() - allocations.Load()
+	return nil
+}
+
+func runOnce(ctx context.Context, config Config, metrics map[string]int64) error {
+	once := sync.Once{}
+	executed := 0
+	var calls atomic.Int64
+
+	group := sync.WaitGroup{}
+	for i := 0; i < config.Goroutines; i++ {
+		group.Add(1)
+		go func() {
+			defer group.Done()
+			for j := 0; j < config.Iterations; j++ {
+				once.Do(func() { executed++ })
+				calls.Add(1)
+			}
+		}()
+	}
+	group.Wait()
+
+	metrics["calls"] = calls.Load()
+	metrics["executed"] = int64(executed)
+	return nil
+}
+
+func runMap(ctx context.Context, config Config, metrics map[string]int64) error {
+	data := &sync.Map{}
+	group := &sync.WaitGroup{}
+	for i := 0; i < config.Goroutines; i++ {
+		// Semua Add dilakukan sebelum goroutine berjalan, AddToMap memanggil Done sekali per nilai
+		group.Add(config.Iterations)
+		go func(offset int) {
+			for j := 0; j < config.Iterations; j++ {
+				demo.AddToMap(data, offset+j, group)
+			}
+		}(i * config.Iterations)
+	}
+	group.Wait()
+
+	entries := int64(0)
+	data.Range(func(key, value any) bool {
+		entries++
+		return true
+	})
+	metrics["entries"] = entries
+	return nil
+}
+
+func runTicker(ctx context.Context, config Config, metrics map[string]int64) error {
+	ticker := time.NewTicker(config.Delay)
+	defer ticker.Stop()
+
+	previous := time.Now()
+	maxJitter := time.Duration(0)
+	ticks := int64(0)
+	for ticks < int64(config.Iterations) {
+		select {
+		case now := <-ticker.C:
+			ticks++
+			fmt.Fprintln(config.Log, now)
+			jitter := now.Sub(previous) - config.Delay
+			if jitter < 0 {
+				jitter = -jitter
+			}
+			maxJitter = max(maxJitter, jitter)
+			previous = now
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	metrics["ticks"] = ticks
+	metrics["max_jitter_ns"] = int64(maxJitter)
+	return nil
+}
+
+func runTimer(ctx context.Context, config Config, metrics map[string]int64) error {
+	var fired atomic.Int64
+	var maxLate atomic.Int64
+
+	group := sync.WaitGroup{}
+	for i := 0; i < config.Goroutines; i++ {
+		group.Add(1)
+		start := time.Now()
+		timer := time.AfterFunc(config.Delay, func() {
+			defer group.Done()
+			fired.Add(1)
+			late := int64(time.Since(start) - config.Delay)
+			for {
+				current := maxLate.Load()
+				if late <= current || maxLate.CompareAndSwap(current, late) {
+					break
+				}
+			}
+			fmt.Fprintln(config.Log, "Execute after", config.Delay)
+		})
+		defer timer.Stop()
+	}
+
+	done := make(chan struct{})
+	go func() {
+		group.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	metrics["fired"] = fired.Load()
+	metrics["max_late_ns"] = maxLate.Load()
+	return nil
+}
+
+func runGomaxprocs(ctx context.Context, config Config, metrics map[string]int64) error {
+	release := make(chan struct{})
+	group := sync.WaitGroup{}
+	for i := 0; i < config.Goroutines; i++ {
+		group.Add(1)
+		go func() {
+			defer group.Done()
+			<-release
+		}()
+	}
+
+	metrics["cpus"] = int64(runtime.NumCPU())
+	metrics["gomaxprocs"] = int64(runtime.GOMAXPROCS(-1))
+	metrics["goroutines"] = int64(runtime.NumGoroutine())
+
+	// Goroutine tetap hidup selama Delay seperti time.Sleep pada TestGetGomaxprocs
+	select {
+	case <-time.After(config.Delay):
+	case <-ctx.Done():
+	}
+	close(release)
+	group.Wait()
+	return ctx.Err()
+}
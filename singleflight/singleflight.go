@@ -0,0 +1,177 @@
+// Package singleflight berisi deduplikasi pemanggilan fungsi per key. Berbeda dengan sync.Once
+// yang menjalankan fungsi sekali untuk selamanya, Group menjalankan fungsi sekali per key selama
+// pemanggilan masih berjalan, dan semua pemanggil lain dengan key yang sama menunggu hasilnya
+package singleflight
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"runtime/debug"
+	"sync"
+)
+
+// errGoexit menandai fungsi yang berhenti karena runtime.Goexit, misalnya t.FailNow di dalam test
+var errGoexit = errors.New("singleflight: runtime.Goexit dipanggil")
+
+// PanicError adalah nilai panic yang diteruskan ke semua pemanggil jika fungsi panic
+type PanicError struct {
+	Value any    // Nilai asli yang dipakai saat panic
+	Stack []byte // Stack trace goroutine yang menjalankan fungsi
+}
+
+// Error mengembalikan nilai panic beserta stack trace
+func (err *PanicError) Error() string {
+	return fmt.Sprintf("%v\n\n%s", err.Value, err.Stack)
+}
+
+// Unwrap mengembalikan error asli jika nilai panic adalah error
+func (err *PanicError) Unwrap() error {
+	if cause, ok := err.Value.(error); ok {
+		return cause
+	}
+	return nil
+}
+
+// Result adalah hasil pemanggilan yang dikirim oleh DoChan
+type Result[V any] struct {
+	Value  V
+	Err    error
+	Shared bool // true jika hasil ini juga diterima pemanggil lain
+}
+
+// call adalah satu pemanggilan fungsi yang sedang berjalan atau sudah selesai
+type call[V any] struct {
+	group sync.WaitGroup
+
+	value V
+	err   error
+
+	duplicates int              // Jumlah pemanggil lain yang menunggu hasil yang sama
+	channels   []chan Result[V] // Channel milik pemanggil DoChan
+}
+
+// Group menjalankan fungsi sekali per key selama pemanggilan masih berjalan.
+// Zero value siap dipakai
+type Group[K comparable, V any] struct {
+	mutex sync.Mutex
+	calls map[K]*call[V]
+}
+
+// Do menjalankan fn untuk key dan mengembalikan hasilnya. Jika pemanggilan dengan key yang sama
+// sedang berjalan, Do menunggu dan mengembalikan hasil pemanggilan tersebut; shared bernilai true
+// jika hasilnya diterima lebih dari satu pemanggil.
+// Jika fn panic, semua pemanggil panic dengan *PanicError, dan jika fn memanggil runtime.Goexit,
+// semua pemanggil juga berhenti dengan runtime.Goexit
+func (group *Group[K, V]) Do(key K, fn func() (V, error)) (value V, err error, shared bool) {
+	group.mutex.Lock()
+	if group.calls == nil {
+		group.calls = make(map[K]*call[V])
+	}
+	if existing, ok := group.calls[key]; ok {
+		existing.duplicates++
+		group.mutex.Unlock()
+		existing.group.Wait()
+		return existing.result(true)
+	}
+	c := &call[V]{}
+	c.group.Add(1)
+	group.calls[key] = c
+	group.mutex.Unlock()
+
+	group.run(key, c, fn)
+	return c.result(c.duplicates > 0)
+}
+
+// DoChan sama seperti Do tetapi mengembalikan channel yang menerima hasilnya, sehingga pemanggil
+// dapat berhenti menunggu dengan select. Channel tidak menerima apa pun jika fn memanggil runtime.Goexit
+func (group *Group[K, V]) DoChan(key K, fn func() (V, error)) <-chan Result[V] {
+	channel := make(chan Result[V], 1)
+	group.mutex.Lock()
+	if group.calls == nil {
+		group.calls = make(map[K]*call[V])
+	}
+	if existing, ok := group.calls[key]; ok {
+		existing.duplicates++
+		existing.channels = append(existing.channels, channel)
+		group.mutex.Unlock()
+		return channel
+	}
+	c := &call[V]{channels: []chan Result[V]{channel}}
+	c.group.Add(1)
+	group.calls[key] = c
+	group.mutex.Unlock()
+
+	go group.run(key, c, fn)
+	return channel
+}
+
+// Forget melupakan pemanggilan key yang sedang berjalan, sehingga Do berikutnya menjalankan fn lagi
+// tanpa menunggu pemanggilan sebelumnya
+func (group *Group[K, V]) Forget(key K) {
+	group.mutex.Lock()
+	delete(group.calls, key)
+	group.mutex.Unlock()
+}
+
+// run menjalankan fn, menyimpan hasilnya, lalu membangunkan semua pemanggil yang menunggu
+func (group *Group[K, V]) run(key K, c *call[V], fn func() (V, error)) {
+	normalReturn := false
+	recovered := false
+
+	defer func() {
+		// fn tidak kembali normal dan tidak panic, berarti runtime.Goexit dipanggil
+		if !normalReturn && !recovered {
+			c.err = errGoexit
+		}
+
+		group.mutex.Lock()
+		defer group.mutex.Unlock()
+		c.group.Done()
+		if group.calls[key] == c {
+			delete(group.calls, key)
+		}
+
+		if panicErr, ok := c.err.(*PanicError); ok && len(c.channels) > 0 {
+			// Pemanggil DoChan tidak bisa menerima panic, sehingga panic diulang di goroutine baru
+			// agar program berhenti dan tidak ada pemanggil yang menunggu selamanya
+			go panic(panicErr)
+			select {}
+		}
+		if c.err == errGoexit {
+			// Goroutine ini sudah sedang menjalankan Goexit, tidak ada yang perlu dikirim
+			return
+		}
+		for _, channel := range c.channels {
+			channel <- Result[V]{Value: c.value, Err: c.err, Shared: c.duplicates > 0}
+		}
+	}()
+
+	func() {
+		defer func() {
+			if !normalReturn {
+				// recover mengembalikan nil ketika runtime.Goexit dipanggil
+				if value := recover(); value != nil {
+					c.err = &PanicError{Value: value, Stack: debug.Stack()}
+				}
+			}
+		}()
+		c.value, c.err = fn()
+		normalReturn = true
+	}()
+
+	if !normalReturn {
+		recovered = true
+	}
+}
+
+// result mengembalikan hasil call kepada pemanggil Do, meneruskan panic atau Goexit jika ada
+func (c *call[V]) result(shared bool) (V, error, bool) {
+	if panicErr, ok := c.err.(*PanicError); ok {
+		panic(panicErr)
+	}
+	if c.err == errGoexit {
+		runtime.Goexit()
+	}
+	return c.value, c.err, shared
+}
@@ -0,0 +1,199 @@
+package singleflight
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestDo menjalankan 100 goroutine per key seperti TestOnce dan memastikan fungsi hanya
+// dijalankan sekali per key selama pemanggilan masih berjalan
+func TestDo(t *testing.T) {
+	var group Group[string, int]
+	var calls atomic.Int32
+	release := make(chan struct{})
+
+	waitGroup := sync.WaitGroup{}
+	var shared atomic.Int32
+	for _, key := range []string{"a", "b", "c"} {
+		for i := 0; i < 100; i++ {
+			waitGroup.Add(1)
+			go func() {
+				defer waitGroup.Done()
+				value, err, isShared := group.Do(key, func() (int, error) {
+					calls.Add(1)
+					<-release
+					return len(key), nil
+				})
+				if err != nil || value != 1 {
+					t.Errorf("value = %d, err = %v", value, err)
+				}
+				if isShared {
+					shared.Add(1)
+				}
+			}()
+		}
+	}
+
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	waitGroup.Wait()
+
+	if calls.Load() != 3 {
+		t.Fatalf("fungsi dijalankan %d kali, seharusnya 3", calls.Load())
+	}
+	if shared.Load() != 300 {
+		t.Fatalf("hasil shared = %d, seharusnya 300", shared.Load())
+	}
+
+	// Setelah pemanggilan selesai, Do berikutnya menjalankan fungsi lagi
+	_, _, isShared := group.Do("a", func() (int, error) {
+		calls.Add(1)
+		return 1, nil
+	})
+	if calls.Load() != 4 || isShared {
+		t.Fatalf("calls = %d, shared = %v", calls.Load(), isShared)
+	}
+}
+
+// TestDoError memastikan error diteruskan ke semua pemanggil
+func TestDoError(t *testing.T) {
+	var group Group[int, string]
+	failure := errors.New("gagal")
+	_, err, _ := group.Do(1, func() (string, error) { return "", failure })
+	if !errors.Is(err, failure) {
+		t.Fatalf("err = %v", err)
+	}
+}
+
+// TestDoChan memastikan dua pemanggil DoChan berbagi satu pemanggilan
+func TestDoChan(t *testing.T) {
+	var group Group[string, string]
+	release := make(chan struct{})
+	var calls atomic.Int32
+	fn := func() (string, error) {
+		calls.Add(1)
+		<-release
+		return "hasil", nil
+	}
+
+	first := group.DoChan("key", fn)
+	second := group.DoChan("key", fn)
+	close(release)
+
+	for _, channel := range []<-chan Result[string]{first, second} {
+		result := <-channel
+		if result.Value != "hasil" || result.Err != nil || !result.Shared {
+			t.Fatalf("result = %+v", result)
+		}
+	}
+	if calls.Load() != 1 {
+		t.Fatalf("fungsi dijalankan %d kali", calls.Load())
+	}
+}
+
+// TestForget memastikan Do setelah Forget tidak menunggu pemanggilan sebelumnya
+func TestForget(t *testing.T) {
+	var group Group[string, int]
+	release := make(chan struct{})
+	first := group.DoChan("key", func() (int, error) {
+		<-release
+		return 1, nil
+	})
+
+	group.Forget("key")
+	value, _, _ := group.Do("key", func() (int, error) { return 2, nil })
+	if value != 2 {
+		t.Fatalf("value = %d, seharusnya dari pemanggilan baru", value)
+	}
+
+	close(release)
+	if result := <-first; result.Value != 1 {
+		t.Fatalf("pemanggilan lama = %d", result.Value)
+	}
+}
+
+// TestPanic memastikan panic diteruskan ke semua pemanggil Do sebagai *PanicError
+func TestPanic(t *testing.T) {
+	var group Group[string, int]
+	release := make(chan struct{})
+	recovered := make(chan any, 10)
+
+	waitGroup := sync.WaitGroup{}
+	for i := 0; i < 10; i++ {
+		waitGroup.Add(1)
+		go func() {
+			defer waitGroup.Done()
+			defer func() { recovered <- recover() }()
+			group.Do("key", func() (int, error) {
+				<-release
+				panic("meledak")
+			})
+		}()
+	}
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	waitGroup.Wait()
+	close(recovered)
+
+	count := 0
+	for value := range recovered {
+		panicErr, ok := value.(*PanicError)
+		if !ok || panicErr.Value != "meledak" || len(panicErr.Stack) == 0 {
+			t.Fatalf("recover = %v", value)
+		}
+		count++
+	}
+	if count != 10 {
+		t.Fatalf("panic diterima %d pemanggil", count)
+	}
+}
+
+// TestGoexit memastikan runtime.Goexit di dalam fungsi juga menghentikan semua pemanggil
+// tanpa membuat mereka menunggu selamanya
+func TestGoexit(t *testing.T) {
+	var group Group[string, int]
+	release := make(chan struct{})
+	returned := make(chan bool, 10)
+
+	waitGroup := sync.WaitGroup{}
+	for i := 0; i < 10; i++ {
+		waitGroup.Add(1)
+		go func() {
+			defer waitGroup.Done()
+			normal := false
+			defer func() { returned <- normal }()
+			group.Do("key", func() (int, error) {
+				<-release
+				// runtime.Goexit sama seperti yang dipanggil t.FailNow
+				runtime.Goexit()
+				return 0, nil
+			})
+			normal = true
+		}()
+	}
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	waitGroup.Wait()
+	close(returned)
+
+	for normal := range returned {
+		if normal {
+			t.Fatal("Do seharusnya tidak kembali normal setelah Goexit")
+		}
+	}
+}
+
+// ExampleGroup menunjukkan hasil Do untuk satu pemanggil, sehingga shared bernilai false
+func ExampleGroup() {
+	var group Group[string, string]
+	value, err, shared := group.Do("user:1", func() (string, error) {
+		return "Aidil", nil
+	})
+	fmt.Println(value, err, shared)
+	// Output: Aidil <nil> false
+}
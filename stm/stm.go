@@ -0,0 +1,221 @@
+// Package stm berisi software transactional memory sederhana. Transaksi membaca dan menulis
+// TVar secara optimistik, lalu saat commit memvalidasi bahwa versi semua TVar yang dibaca
+// belum berubah. Jika berubah, transaksi diulang, sehingga banyak update dapat dikomposisikan
+// tanpa lock bersarang dan tanpa deadlock
+package stm
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"sync/atomic"
+)
+
+// Retry dikembalikan dari fungsi transaksi untuk menunggu sampai salah satu TVar yang sudah
+// dibaca berubah, lalu menjalankan ulang transaksi. Contoh: menunggu saldo cukup sebelum transfer
+var Retry = errors.New("stm: retry")
+
+// errConflict dipakai secara internal (lewat panic) ketika transaksi membaca data yang tidak konsisten
+var errConflict = errors.New("stm: conflict")
+
+// nextID memberi ID unik untuk setiap TVar, dipakai untuk urutan penguncian saat commit
+var nextID atomic.Uint64
+
+// commitLock dan commitCond membangunkan transaksi yang menunggu karena Retry setiap kali ada commit
+var (
+	commitLock sync.Mutex
+	commitCond = sync.NewCond(&commitLock)
+)
+
+// tvar adalah bagian non-generic dari TVar yang dikelola oleh transaksi
+type tvar struct {
+	id      uint64
+	mutex   sync.Mutex
+	version uint64
+	value   any
+}
+
+func (variable *tvar) load() (any, uint64) {
+	variable.mutex.Lock()
+	defer variable.mutex.Unlock()
+	return variable.value, variable.version
+}
+
+func (variable *tvar) currentVersion() uint64 {
+	variable.mutex.Lock()
+	defer variable.mutex.Unlock()
+	return variable.version
+}
+
+// TVar adalah variabel transaksional yang hanya boleh diubah di dalam Atomically
+type TVar[T any] struct {
+	base tvar
+}
+
+// NewTVar membuat TVar dengan nilai awal value
+func NewTVar[T any](value T) *TVar[T] {
+	variable := &TVar[T]{}
+	variable.base.id = nextID.Add(1)
+	variable.base.value = value
+	return variable
+}
+
+// Get membaca nilai TVar di dalam transaksi
+func (variable *TVar[T]) Get(tx *Tx) T {
+	// Bentuk dua nilai agar nil tidak panic ketika T adalah interface
+	value, _ := tx.read(&variable.base).(T)
+	return value
+}
+
+// Set menulis nilai TVar di dalam transaksi, nilai baru baru terlihat oleh goroutine lain setelah commit
+func (variable *TVar[T]) Set(tx *Tx, value T) {
+	tx.writes[&variable.base] = value
+}
+
+// Load membaca nilai TVar yang sudah di-commit tanpa transaksi
+func (variable *TVar[T]) Load() T {
+	value, _ := variable.base.load()
+	typed, _ := value.(T)
+	return typed
+}
+
+// Tx adalah transaksi yang sedang berjalan, hanya boleh dipakai oleh goroutine pemiliknya
+type Tx struct {
+	reads  map[*tvar]uint64 // Versi TVar saat pertama kali dibaca
+	writes map[*tvar]any    // Nilai yang akan ditulis saat commit
+}
+
+// read mengembalikan nilai dari write set jika ada, atau dari TVar sambil mencatat versinya.
+// Setiap read memvalidasi ulang read set agar fungsi transaksi tidak pernah melihat data yang tidak konsisten
+func (tx *Tx) read(variable *tvar) any {
+	if value, ok := tx.writes[variable]; ok {
+		return value
+	}
+	value, version := variable.load()
+	if recorded, ok := tx.reads[variable]; ok && recorded != version {
+		panic(errConflict)
+	}
+	tx.reads[variable] = version
+	if !tx.valid() {
+		panic(errConflict)
+	}
+	return value
+}
+
+// valid memeriksa bahwa semua TVar di read set masih pada versi yang sama
+func (tx *Tx) valid() bool {
+	for variable, version := range tx.reads {
+		if variable.currentVersion() != version {
+			return false
+		}
+	}
+	return true
+}
+
+// Atomically menjalankan function sebagai satu transaksi. Function dapat dijalankan lebih dari sekali
+// ketika terjadi konflik, sehingga tidak boleh memiliki efek samping di luar TVar.
+// Jika function mengembalikan error selain Retry, transaksi dibatalkan dan error tersebut dikembalikan
+func Atomically(function func(tx *Tx) error) error {
+	for {
+		tx := &Tx{reads: map[*tvar]uint64{}, writes: map[*tvar]any{}}
+		err, conflict := tx.run(function)
+		switch {
+		case conflict:
+			continue
+		case errors.Is(err, Retry):
+			tx.wait()
+			continue
+		case err != nil:
+			return err
+		}
+		if tx.commit() {
+			return nil
+		}
+	}
+}
+
+// run menjalankan function dan mengubah panic errConflict menjadi nilai conflict
+func (tx *Tx) run(function func(tx *Tx) error) (err error, conflict bool) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			if recovered != errConflict {
+				panic(recovered)
+			}
+			conflict = true
+		}
+	}()
+	return function(tx), false
+}
+
+// commit mengunci semua TVar yang terlibat berdasarkan urutan ID (sehingga commit tidak bisa
+// deadlock), memvalidasi read set, lalu menerapkan write set dan menaikkan versinya
+func (tx *Tx) commit() bool {
+	if len(tx.writes) == 0 {
+		return tx.valid()
+	}
+
+	variables := make([]*tvar, 0, len(tx.reads)+len(tx.writes))
+	for variable := range tx.writes {
+		variables = append(variables, variable)
+	}
+	for variable := range tx.reads {
+		if _, ok := tx.writes[variable]; !ok {
+			variables = append(variables, variable)
+		}
+	}
+	sort.Slice(variables, func(i, j int) bool { return variables[i].id < variables[j].id })
+
+	for _, variable := range variables {
+		variable.mutex.Lock()
+	}
+	valid := true
+	for variable, version := range tx.reads {
+		if variable.version != version {
+			valid = false
+			break
+		}
+	}
+	if valid {
+		for variable, value := range tx.writes {
+			variable.value = value
+			variable.version++
+		}
+	}
+	for _, variable := range variables {
+		variable.mutex.Unlock()
+	}
+
+	if valid {
+		commitLock.Lock()
+		commitCond.Broadcast()
+		commitLock.Unlock()
+	}
+	return valid
+}
+
+// wait menunggu sampai salah satu TVar di read set di-commit oleh transaksi lain
+func (tx *Tx) wait() {
+	commitLock.Lock()
+	defer commitLock.Unlock()
+	for tx.valid() {
+		commitCond.Wait()
+	}
+}
+
+// OrElse menggabungkan dua alternatif: first dijalankan lebih dulu, dan jika first mengembalikan Retry
+// semua tulisan first dibuang lalu second dijalankan. Jika keduanya Retry, transaksi menunggu
+// perubahan pada TVar yang dibaca oleh keduanya
+func OrElse(first, second func(tx *Tx) error) func(tx *Tx) error {
+	return func(tx *Tx) error {
+		saved := make(map[*tvar]any, len(tx.writes))
+		for variable, value := range tx.writes {
+			saved[variable] = value
+		}
+		err := first(tx)
+		if !errors.Is(err, Retry) {
+			return err
+		}
+		tx.writes = saved
+		return second(tx)
+	}
+}
@@ -0,0 +1,197 @@
+package stm
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"sync"
+	"testing"
+	"time"
+)
+
+// transfer memindahkan amount dari from ke to, menunggu (Retry) jika saldo from tidak cukup
+func transfer(from, to *TVar[int], amount int) func(tx *Tx) error {
+	return func(tx *Tx) error {
+		balance := from.Get(tx)
+		if balance < amount {
+			return Retry
+		}
+		from.Set(tx, balance-amount)
+		to.Set(tx, to.Get(tx)+amount)
+		return nil
+	}
+}
+
+// TestTransferConservesTotal menjalankan transfer berlawanan arah secara bersamaan
+// seperti TestDeadlock dan memastikan total saldo tetap sama tanpa deadlock
+func TestTransferConservesTotal(t *testing.T) {
+	user1 := NewTVar(1000000)
+	user2 := NewTVar(1000000)
+
+	group := sync.WaitGroup{}
+	for i := 0; i < 100; i++ {
+		group.Add(2)
+		go func() {
+			defer group.Done()
+			Atomically(transfer(user1, user2, 100000))
+		}()
+		go func() {
+			defer group.Done()
+			Atomically(transfer(user2, user1, 100000))
+		}()
+	}
+	group.Wait()
+
+	if user1.Load()+user2.Load() != 2000000 {
+		t.Fatalf("total = %d", user1.Load()+user2.Load())
+	}
+	if user1.Load() != 1000000 {
+		t.Fatalf("saldo = %d dan %d", user1.Load(), user2.Load())
+	}
+}
+
+// TestRetry memastikan transaksi yang Retry menunggu sampai saldo cukup
+func TestRetry(t *testing.T) {
+	from := NewTVar(0)
+	to := NewTVar(0)
+	done := make(chan struct{})
+	go func() {
+		Atomically(transfer(from, to, 50))
+		close(done)
+	}()
+
+	time.Sleep(10 * time.Millisecond)
+	select {
+	case <-done:
+		t.Fatal("transfer seharusnya menunggu saldo cukup")
+	default:
+	}
+
+	Atomically(func(tx *Tx) error {
+		from.Set(tx, 100)
+		return nil
+	})
+	<-done
+	if from.Load() != 50 || to.Load() != 50 {
+		t.Fatalf("saldo = %d dan %d", from.Load(), to.Load())
+	}
+}
+
+// TestOrElse memastikan alternatif kedua dijalankan dan tulisan alternatif pertama dibuang
+func TestOrElse(t *testing.T) {
+	empty := NewTVar(0)
+	full := NewTVar(100)
+	target := NewTVar(0)
+	touched := NewTVar(false)
+
+	err := Atomically(OrElse(
+		func(tx *Tx) error {
+			touched.Set(tx, true)
+			return transfer(empty, target, 10)(tx)
+		},
+		transfer(full, target, 10),
+	))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if full.Load() != 90 || target.Load() != 10 || touched.Load() {
+		t.Fatalf("full=%d target=%d touched=%v", full.Load(), target.Load(), touched.Load())
+	}
+}
+
+// TestAbort memastikan error dari fungsi transaksi membatalkan semua tulisan
+func TestAbort(t *testing.T) {
+	account := NewTVar(10)
+	failure := errors.New("gagal")
+	err := Atomically(func(tx *Tx) error {
+		account.Set(tx, 20)
+		return failure
+	})
+	if !errors.Is(err, failure) || account.Load() != 10 {
+		t.Fatalf("err = %v, saldo = %d", err, account.Load())
+	}
+}
+
+// TestInterfaceNil memastikan TVar bertipe interface dapat menyimpan dan membaca nil
+func TestInterfaceNil(t *testing.T) {
+	failure := NewTVar[error](nil)
+	err := Atomically(func(tx *Tx) error {
+		if failure.Get(tx) != nil {
+			return errors.New("nilai awal seharusnya nil")
+		}
+		failure.Set(tx, errors.New("gagal"))
+		failure.Set(tx, nil)
+		if failure.Get(tx) != nil {
+			return errors.New("nilai yang ditulis seharusnya nil")
+		}
+		return nil
+	})
+	if err != nil || failure.Load() != nil {
+		t.Fatalf("err = %v, nilai = %v", err, failure.Load())
+	}
+}
+
+// lockedAccount adalah rekening dengan mutex untuk pembanding lock-ordered
+type lockedAccount struct {
+	id      int
+	mutex   sync.Mutex
+	balance int
+}
+
+// lockOrderedTransfer mengunci kedua rekening berdasarkan urutan id untuk menghindari deadlock
+func lockOrderedTransfer(from, to *lockedAccount, amount int) {
+	first, second := from, to
+	if second.id < first.id {
+		first, second = second, first
+	}
+	first.mutex.Lock()
+	second.mutex.Lock()
+	from.balance -= amount
+	to.balance += amount
+	second.mutex.Unlock()
+	first.mutex.Unlock()
+}
+
+// BenchmarkTransfer membandingkan STM dengan lock ordering pada jumlah rekening yang berbeda.
+// Semakin sedikit rekening, semakin tinggi contention karena transfer sering menyentuh rekening yang sama
+func BenchmarkTransfer(b *testing.B) {
+	for _, accounts := range []int{2, 16, 256} {
+		b.Run(fmt.Sprintf("STM/accounts=%d", accounts), func(b *testing.B) {
+			variables := make([]*TVar[int], accounts)
+			for i := range variables {
+				variables[i] = NewTVar(1000000)
+			}
+			b.RunParallel(func(pb *testing.PB) {
+				random := rand.New(rand.NewSource(rand.Int63()))
+				for pb.Next() {
+					from, to := random.Intn(accounts), random.Intn(accounts)
+					if from == to {
+						to = (to + 1) % accounts
+					}
+					Atomically(func(tx *Tx) error {
+						variables[from].Set(tx, variables[from].Get(tx)-1)
+						variables[to].Set(tx, variables[to].Get(tx)+1)
+						return nil
+					})
+				}
+			})
+		})
+
+		b.Run(fmt.Sprintf("LockOrdered/accounts=%d", accounts), func(b *testing.B) {
+			locked := make([]*lockedAccount, accounts)
+			for i := range locked {
+				locked[i] = &lockedAccount{id: i, balance: 1000000}
+			}
+			b.RunParallel(func(pb *testing.PB) {
+				random := rand.New(rand.NewSource(rand.Int63()))
+				for pb.Next() {
+					from, to := random.Intn(accounts), random.Intn(accounts)
+					if from == to {
+						to = (to + 1) % accounts
+					}
+					lockOrderedTransfer(locked[from], locked[to], 1)
+				}
+			})
+		})
+	}
+}
@@ -0,0 +1,89 @@
+// Package syncx berisi primitive sinkronisasi tambahan yang melengkapi package sync
+package syncx
+
+import (
+	"sync"
+	"time"
+)
+
+// DefaultBuckets adalah batas atas bucket histogram yang dipakai jika tidak ditentukan,
+// mulai dari 1 mikrodetik sampai 10 detik
+var DefaultBuckets = []time.Duration{
+	1 * time.Microsecond,
+	10 * time.Microsecond,
+	100 * time.Microsecond,
+	1 * time.Millisecond,
+	10 * time.Millisecond,
+	100 * time.Millisecond,
+	1 * time.Second,
+	10 * time.Second,
+}
+
+// Histogram mencatat sebaran durasi ke dalam bucket dengan batas atas tetap
+type Histogram struct {
+	mutex  sync.Mutex
+	bounds []time.Duration
+	counts []uint64 // counts[len(bounds)] adalah bucket +Inf
+	count  uint64
+	sum    time.Duration
+}
+
+// NewHistogram membuat histogram dengan batas atas bucket yang diberikan secara berurutan,
+// atau DefaultBuckets jika bounds kosong
+func NewHistogram(bounds ...time.Duration) *Histogram {
+	if len(bounds) == 0 {
+		bounds = DefaultBuckets
+	}
+	return &Histogram{
+		bounds: bounds,
+		counts: make([]uint64, len(bounds)+1),
+	}
+}
+
+// Observe mencatat satu durasi ke bucket yang sesuai
+func (histogram *Histogram) Observe(duration time.Duration) {
+	histogram.mutex.Lock()
+	defer histogram.mutex.Unlock()
+
+	index := len(histogram.bounds)
+	for i, bound := range histogram.bounds {
+		if duration <= bound {
+			index = i
+			break
+		}
+	}
+	histogram.counts[index]++
+	histogram.count++
+	histogram.sum += duration
+}
+
+// Bucket adalah jumlah kumulatif observasi yang nilainya <= UpperBound
+type Bucket struct {
+	UpperBound time.Duration // Batas atas bucket, bucket terakhir (+Inf) tidak disertakan
+	Count      uint64        // Jumlah kumulatif seperti pada histogram Prometheus
+}
+
+// HistogramSnapshot adalah salinan isi histogram pada satu titik waktu
+type HistogramSnapshot struct {
+	Buckets []Bucket      // Bucket kumulatif tanpa bucket +Inf
+	Count   uint64        // Total observasi, sama dengan bucket +Inf
+	Sum     time.Duration // Total durasi dari semua observasi
+}
+
+// Snapshot mengambil salinan histogram dengan count kumulatif
+func (histogram *Histogram) Snapshot() HistogramSnapshot {
+	histogram.mutex.Lock()
+	defer histogram.mutex.Unlock()
+
+	snapshot := HistogramSnapshot{
+		Buckets: make([]Bucket, len(histogram.bounds)),
+		Count:   histogram.count,
+		Sum:     histogram.sum,
+	}
+	var cumulative uint64
+	for i, bound := range histogram.bounds {
+		cumulative += histogram.counts[i]
+		snapshot.Buckets[i] = Bucket{UpperBound: bound, Count: cumulative}
+	}
+	return snapshot
+}
@@ -0,0 +1,266 @@
+package syncx
+
+import (
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// SlowHold mencatat lock yang ditahan lebih lama dari threshold beserta stack pemegangnya
+type SlowHold struct {
+	Duration time.Duration // Lama lock ditahan
+	Stack    string        // Stack goroutine saat lock diperoleh
+	At       time.Time     // Waktu lock dilepas
+}
+
+// LockStats adalah ringkasan metric dari satu lock untuk satu mode akses
+type LockStats struct {
+	Name        string            // Nama lock, dipakai sebagai label
+	Mode        string            // "write" atau "read"
+	Contended   uint64            // Jumlah akuisisi yang harus menunggu
+	Uncontended uint64            // Jumlah akuisisi yang langsung berhasil
+	Wait        HistogramSnapshot // Sebaran waktu tunggu sebelum lock diperoleh
+	Hold        HistogramSnapshot // Sebaran lama lock ditahan (hanya mode write)
+	SlowHolds   []SlowHold        // Catatan hold yang melewati threshold, maksimal MaxSlowHolds
+}
+
+// MaxSlowHolds adalah jumlah maksimal catatan SlowHold yang disimpan per lock
+const MaxSlowHolds = 16
+
+// lockMetrics menyimpan metric mentah yang dipakai bersama oleh lock instrumented
+type lockMetrics struct {
+	once        sync.Once
+	contended   atomic.Uint64
+	uncontended atomic.Uint64
+	wait        *Histogram
+	hold        *Histogram
+	waiters     atomic.Int64 // Goroutine yang sedang menunggu lock
+
+	slowMutex sync.Mutex
+	slowHolds []SlowHold
+}
+
+func (metrics *lockMetrics) init() {
+	metrics.once.Do(func() {
+		metrics.wait = NewHistogram()
+		metrics.hold = NewHistogram()
+	})
+}
+
+// acquire menjalankan tryLock terlebih dahulu, dan jika gagal memanggil lock sambil mengukur waktu tunggu
+func (metrics *lockMetrics) acquire(tryLock func() bool, lock func()) {
+	metrics.init()
+	if tryLock() {
+		metrics.uncontended.Add(1)
+		metrics.wait.Observe(0)
+		return
+	}
+	start := time.Now()
+	metrics.waiters.Add(1)
+	lock()
+	metrics.waiters.Add(-1)
+	metrics.contended.Add(1)
+	metrics.wait.Observe(time.Since(start))
+}
+
+func (metrics *lockMetrics) recordSlowHold(hold SlowHold) {
+	metrics.slowMutex.Lock()
+	defer metrics.slowMutex.Unlock()
+	if len(metrics.slowHolds) == MaxSlowHolds {
+		metrics.slowHolds = metrics.slowHolds[1:]
+	}
+	metrics.slowHolds = append(metrics.slowHolds, hold)
+}
+
+func (metrics *lockMetrics) stats(name, mode string) LockStats {
+	metrics.init()
+	metrics.slowMutex.Lock()
+	slowHolds := append([]SlowHold(nil), metrics.slowHolds...)
+	metrics.slowMutex.Unlock()
+	return LockStats{
+		Name:        name,
+		Mode:        mode,
+		Contended:   metrics.contended.Load(),
+		Uncontended: metrics.uncontended.Load(),
+		Wait:        metrics.wait.Snapshot(),
+		Hold:        metrics.hold.Snapshot(),
+		SlowHolds:   slowHolds,
+	}
+}
+
+// holder menyimpan informasi pemegang lock eksklusif saat ini
+type holder struct {
+	acquiredAt time.Time
+	callers    [32]uintptr
+	depth      int
+}
+
+// capture menyimpan waktu akuisisi dan program counter pemanggil tanpa simbolisasi,
+// agar biaya per Lock tetap kecil; stack baru disimbolisasi jika hold ternyata lambat
+func (holder *holder) capture(withStack bool) {
+	holder.acquiredAt = time.Now()
+	holder.depth = 0
+	if withStack {
+		holder.depth = runtime.Callers(3, holder.callers[:])
+	}
+}
+
+func (holder *holder) stack() string {
+	var builder strings.Builder
+	frames := runtime.CallersFrames(holder.callers[:holder.depth])
+	for {
+		frame, more := frames.Next()
+		builder.WriteString(frame.Function)
+		builder.WriteString("\n\t")
+		builder.WriteString(frame.File)
+		builder.WriteString(":")
+		builder.WriteString(strconv.Itoa(frame.Line))
+		builder.WriteString("\n")
+		if !more {
+			break
+		}
+	}
+	return builder.String()
+}
+
+// release mencatat lama hold dan menyimpan SlowHold jika melewati threshold
+func (holder *holder) release(metrics *lockMetrics, threshold time.Duration, onSlowHold func(SlowHold)) {
+	duration := time.Since(holder.acquiredAt)
+	metrics.hold.Observe(duration)
+	if threshold <= 0 || duration < threshold {
+		return
+	}
+	hold := SlowHold{Duration: duration, Stack: holder.stack(), At: time.Now()}
+	metrics.recordSlowHold(hold)
+	if onSlowHold != nil {
+		onSlowHold(hold)
+	}
+}
+
+// InstrumentedMutex adalah pengganti sync.Mutex yang mencatat waktu tunggu, lama hold,
+// jumlah akuisisi contended/uncontended, dan hold yang melewati SlowHoldThreshold.
+// Zero value siap dipakai dan mengimplementasikan sync.Locker
+type InstrumentedMutex struct {
+	Name              string         // Nama lock untuk label metric
+	SlowHoldThreshold time.Duration  // Batas hold lambat, 0 berarti tidak dicatat
+	OnSlowHold        func(SlowHold) // Callback opsional ketika hold lambat terdeteksi
+	TrackHolder       bool           // Mencatat ID goroutine pemegang lock untuk State
+
+	mutex   sync.Mutex
+	metrics lockMetrics
+	holder  holder
+	owner   owner
+}
+
+// Lock mengunci mutex sambil mencatat waktu tunggunya
+func (mutex *InstrumentedMutex) Lock() {
+	mutex.metrics.acquire(mutex.mutex.TryLock, mutex.mutex.Lock)
+	mutex.holder.capture(mutex.SlowHoldThreshold > 0)
+	mutex.owner.acquired(mutex.TrackHolder)
+}
+
+// TryLock mencoba mengunci mutex tanpa menunggu
+func (mutex *InstrumentedMutex) TryLock() bool {
+	mutex.metrics.init()
+	if !mutex.mutex.TryLock() {
+		return false
+	}
+	mutex.metrics.uncontended.Add(1)
+	mutex.metrics.wait.Observe(0)
+	mutex.holder.capture(mutex.SlowHoldThreshold > 0)
+	mutex.owner.acquired(mutex.TrackHolder)
+	return true
+}
+
+// Unlock membuka mutex dan mencatat lama lock ditahan
+func (mutex *InstrumentedMutex) Unlock() {
+	mutex.owner.released()
+	mutex.holder.release(&mutex.metrics, mutex.SlowHoldThreshold, mutex.OnSlowHold)
+	mutex.mutex.Unlock()
+}
+
+// Stats mengembalikan ringkasan metric mutex
+func (mutex *InstrumentedMutex) Stats() LockStats {
+	return mutex.metrics.stats(mutex.Name, "write")
+}
+
+// State mengembalikan keadaan mutex saat ini
+func (mutex *InstrumentedMutex) State() LockState {
+	state := mutex.owner.state(mutex.Name)
+	state.Waiters = mutex.metrics.waiters.Load()
+	return state
+}
+
+// InstrumentedRWMutex adalah pengganti sync.RWMutex dengan metric terpisah untuk write dan read.
+// Lama hold hanya dicatat untuk write lock karena banyak reader dapat memegang lock bersamaan
+type InstrumentedRWMutex struct {
+	Name              string         // Nama lock untuk label metric
+	SlowHoldThreshold time.Duration  // Batas hold write yang dianggap lambat, 0 berarti tidak dicatat
+	OnSlowHold        func(SlowHold) // Callback opsional ketika hold lambat terdeteksi
+	TrackHolder       bool           // Mencatat ID goroutine pemegang write lock untuk State
+
+	mutex       sync.RWMutex
+	metrics     lockMetrics
+	readMetrics lockMetrics
+	holder      holder
+	owner       owner
+	readers     atomic.Int64
+}
+
+// Lock mengunci untuk operasi write
+func (mutex *InstrumentedRWMutex) Lock() {
+	mutex.metrics.acquire(mutex.mutex.TryLock, mutex.mutex.Lock)
+	mutex.holder.capture(mutex.SlowHoldThreshold > 0)
+	mutex.owner.acquired(mutex.TrackHolder)
+}
+
+// Unlock membuka write lock dan mencatat lama lock ditahan
+func (mutex *InstrumentedRWMutex) Unlock() {
+	mutex.owner.released()
+	mutex.holder.release(&mutex.metrics, mutex.SlowHoldThreshold, mutex.OnSlowHold)
+	mutex.mutex.Unlock()
+}
+
+// RLock mengunci untuk operasi read
+func (mutex *InstrumentedRWMutex) RLock() {
+	mutex.readMetrics.acquire(mutex.mutex.TryRLock, mutex.mutex.RLock)
+	mutex.readers.Add(1)
+}
+
+// RUnlock membuka read lock
+func (mutex *InstrumentedRWMutex) RUnlock() {
+	mutex.readers.Add(-1)
+	mutex.mutex.RUnlock()
+}
+
+// RLocker mengembalikan sync.Locker yang memanggil RLock dan RUnlock
+func (mutex *InstrumentedRWMutex) RLocker() sync.Locker {
+	return (*instrumentedReader)(mutex)
+}
+
+type instrumentedReader InstrumentedRWMutex
+
+func (reader *instrumentedReader) Lock()   { (*InstrumentedRWMutex)(reader).RLock() }
+func (reader *instrumentedReader) Unlock() { (*InstrumentedRWMutex)(reader).RUnlock() }
+
+// Stats mengembalikan ringkasan metric write lock
+func (mutex *InstrumentedRWMutex) Stats() LockStats {
+	return mutex.metrics.stats(mutex.Name, "write")
+}
+
+// ReadStats mengembalikan ringkasan metric read lock
+func (mutex *InstrumentedRWMutex) ReadStats() LockStats {
+	return mutex.readMetrics.stats(mutex.Name, "read")
+}
+
+// State mengembalikan keadaan RWMutex saat ini. Waiters menjumlahkan goroutine
+// yang menunggu write lock maupun read lock
+func (mutex *InstrumentedRWMutex) State() LockState {
+	state := mutex.owner.state(mutex.Name)
+	state.Readers = mutex.readers.Load()
+	state.Waiters = mutex.metrics.waiters.Load() + mutex.readMetrics.waiters.Load()
+	return state
+}
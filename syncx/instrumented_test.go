@@ -0,0 +1,186 @@
+package syncx
+
+import (
+	"bytes"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// TestHistogram memastikan bucket histogram bersifat kumulatif seperti histogram Prometheus
+func TestHistogram(t *testing.T) {
+	histogram := NewHistogram(time.Millisecond, 10*time.Millisecond)
+	histogram.Observe(500 * time.Microsecond)
+	histogram.Observe(5 * time.Millisecond)
+	histogram.Observe(time.Second)
+
+	snapshot := histogram.Snapshot()
+	if snapshot.Count != 3 || snapshot.Sum != 500*time.Microsecond+5*time.Millisecond+time.Second {
+		t.Fatalf("snapshot = %+v", snapshot)
+	}
+	if snapshot.Buckets[0].Count != 1 || snapshot.Buckets[1].Count != 2 {
+		t.Fatalf("buckets = %+v", snapshot.Buckets)
+	}
+}
+
+// TestInstrumentedMutex menjalankan pola TestMutex dan memastikan semua akuisisi tercatat
+func TestInstrumentedMutex(t *testing.T) {
+	x := 0
+	mutex := InstrumentedMutex{Name: "counter"}
+	var locker sync.Locker = &mutex
+	group := sync.WaitGroup{}
+
+	for i := 0; i < 100; i++ {
+		group.Add(1)
+		go func() {
+			defer group.Done()
+			for j := 0; j < 100; j++ {
+				locker.Lock()
+				x = x + 1
+				locker.Unlock()
+			}
+		}()
+	}
+	group.Wait()
+
+	stats := mutex.Stats()
+	if x != 10000 {
+		t.Fatalf("counter = %d", x)
+	}
+	if stats.Contended+stats.Uncontended != 10000 || stats.Wait.Count != 10000 || stats.Hold.Count != 10000 {
+		t.Fatalf("stats = %+v", stats)
+	}
+}
+
+// TestInstrumentedMutexContended memastikan goroutine yang menunggu tercatat sebagai contended
+func TestInstrumentedMutexContended(t *testing.T) {
+	mutex := InstrumentedMutex{}
+	mutex.Lock()
+	done := make(chan struct{})
+	go func() {
+		mutex.Lock()
+		mutex.Unlock()
+		close(done)
+	}()
+	time.Sleep(20 * time.Millisecond)
+	mutex.Unlock()
+	<-done
+
+	stats := mutex.Stats()
+	if stats.Contended != 1 || stats.Uncontended != 1 {
+		t.Fatalf("stats = %+v", stats)
+	}
+	if stats.Wait.Sum < 20*time.Millisecond {
+		t.Fatalf("waktu tunggu terlalu kecil: %v", stats.Wait.Sum)
+	}
+}
+
+// TestSlowHold memastikan hold yang melewati threshold disimpan bersama stack pemegangnya
+func TestSlowHold(t *testing.T) {
+	var reported []SlowHold
+	mutex := InstrumentedRWMutex{
+		Name:              "account",
+		SlowHoldThreshold: 10 * time.Millisecond,
+		OnSlowHold:        func(hold SlowHold) { reported = append(reported, hold) },
+	}
+
+	mutex.Lock()
+	mutex.Unlock()
+	mutex.Lock()
+	time.Sleep(15 * time.Millisecond)
+	mutex.Unlock()
+
+	stats := mutex.Stats()
+	if len(stats.SlowHolds) != 1 || len(reported) != 1 {
+		t.Fatalf("slow holds = %+v", stats.SlowHolds)
+	}
+	if !strings.Contains(stats.SlowHolds[0].Stack, "TestSlowHold") {
+		t.Fatalf("stack tidak berisi pemegang lock:\n%s", stats.SlowHolds[0].Stack)
+	}
+}
+
+// TestWritePrometheus memastikan dump metric berisi histogram dan counter untuk setiap mode
+func TestWritePrometheus(t *testing.T) {
+	mutex := InstrumentedRWMutex{Name: "account"}
+	mutex.Lock()
+	mutex.Unlock()
+	mutex.RLocker().Lock()
+	mutex.RLocker().Unlock()
+
+	var buffer bytes.Buffer
+	if err := WritePrometheus(&buffer, mutex.Stats(), mutex.ReadStats()); err != nil {
+		t.Fatal(err)
+	}
+	output := buffer.String()
+	for _, expected := range []string{
+		`# TYPE syncx_lock_wait_seconds histogram`,
+		`syncx_lock_acquisitions_total{lock="account",mode="write",contended="false"} 1`,
+		`syncx_lock_wait_seconds_bucket{lock="account",mode="read",le="+Inf"} 1`,
+		`syncx_lock_hold_seconds_count{lock="account",mode="write"} 1`,
+	} {
+		if !strings.Contains(output, expected) {
+			t.Fatalf("output tidak berisi %q:\n%s", expected, output)
+		}
+	}
+}
+
+// TestEscapeLabel memastikan hanya backslash, kutip dan newline yang di-escape, bukan non-ASCII
+func TestEscapeLabel(t *testing.T) {
+	for value, expected := range map[string]string{
+		"rekening":      "rekening",
+		"tabungan ümit": "tabungan ümit",
+		`C:\data`:       `C:\\data`,
+		`"utama"`:       `\"utama\"`,
+		"baris\nbaru":   `baris\nbaru`,
+	} {
+		if escaped := EscapeLabel(value); escaped != expected {
+			t.Fatalf("EscapeLabel(%q) = %s, seharusnya %s", value, escaped, expected)
+		}
+	}
+
+	var buffer bytes.Buffer
+	if err := WritePrometheus(&buffer, (&InstrumentedMutex{Name: "kas \"ü\""}).Stats()); err != nil {
+		t.Fatal(err)
+	}
+	if expected := `lock="kas \"ü\"",mode="write"`; !strings.Contains(buffer.String(), expected) {
+		t.Fatalf("output tidak berisi %s:\n%s", expected, buffer.String())
+	}
+}
+
+// TestLockState memastikan State menunjukkan pemegang lock, reader, dan goroutine yang menunggu
+func TestLockState(t *testing.T) {
+	mutex := InstrumentedMutex{Name: "user1", TrackHolder: true}
+	if state := mutex.State(); state.Held || state.Holder != 0 {
+		t.Fatalf("state = %+v", state)
+	}
+
+	mutex.Lock()
+	waiting := make(chan struct{})
+	go func() {
+		mutex.Lock()
+		mutex.Unlock()
+		close(waiting)
+	}()
+	for mutex.State().Waiters == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	state := mutex.State()
+	if !state.Held || state.Holder != goroutineID() || state.Name != "user1" {
+		t.Fatalf("state = %+v, goroutine = %d", state, goroutineID())
+	}
+	mutex.Unlock()
+	<-waiting
+	if state := mutex.State(); state.Held || state.Waiters != 0 {
+		t.Fatalf("state setelah Unlock = %+v", state)
+	}
+
+	rw := InstrumentedRWMutex{Name: "account"}
+	rw.RLock()
+	rw.RLock()
+	if state := rw.State(); state.Readers != 2 || state.Held {
+		t.Fatalf("state = %+v", state)
+	}
+	rw.RUnlock()
+	rw.RUnlock()
+}
@@ -0,0 +1,64 @@
+package syncx
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// PoolStats adalah statistik InstrumentedPool
+type PoolStats struct {
+	Gets   int64 // Jumlah pemanggilan Get
+	Puts   int64 // Jumlah pemanggilan Put
+	Misses int64 // Get yang tidak menemukan objek di pool sehingga New dipanggil
+}
+
+// Hits mengembalikan jumlah Get yang memakai ulang objek dari pool
+func (stats PoolStats) Hits() int64 {
+	return stats.Gets - stats.Misses
+}
+
+// HitRate mengembalikan perbandingan Hits dengan Gets, 0 jika belum ada Get
+func (stats PoolStats) HitRate() float64 {
+	if stats.Gets == 0 {
+		return 0
+	}
+	return float64(stats.Hits()) / float64(stats.Gets)
+}
+
+// InstrumentedPool adalah sync.Pool yang menghitung Get, Put, dan miss.
+// Seperti sync.Pool, zero value siap dipakai dan InstrumentedPool tidak boleh disalin
+type InstrumentedPool struct {
+	New func() any // Dipanggil ketika pool kosong, jika nil Get mengembalikan nil
+
+	pool   sync.Pool
+	gets   atomic.Int64
+	puts   atomic.Int64
+	misses atomic.Int64
+}
+
+// Get mengambil objek dari pool, atau dari New jika pool kosong
+func (pool *InstrumentedPool) Get() any {
+	pool.gets.Add(1)
+	if value := pool.pool.Get(); value != nil {
+		return value
+	}
+	pool.misses.Add(1)
+	if pool.New == nil {
+		return nil
+	}
+	return pool.New()
+}
+
+// Put mengembalikan objek ke pool, nilai nil diabaikan seperti sync.Pool
+func (pool *InstrumentedPool) Put(value any) {
+	if value == nil {
+		return
+	}
+	pool.puts.Add(1)
+	pool.pool.Put(value)
+}
+
+// Stats mengembalikan statistik pool saat ini
+func (pool *InstrumentedPool) Stats() PoolStats {
+	return PoolStats{Gets: pool.gets.Load(), Puts: pool.puts.Load(), Misses: pool.misses.Load()}
+}
@@ -0,0 +1,24 @@
+package syncx
+
+import "testing"
+
+// TestInstrumentedPool memastikan Get yang memakai ulang objek dihitung sebagai hit.
+// sync.Pool boleh membuang objek (selalu terjadi sesekali dengan -race), sehingga hit
+// dihitung dari nilai yang benar-benar dikembalikan Get
+func TestInstrumentedPool(t *testing.T) {
+	pool := InstrumentedPool{New: func() any { return "New" }}
+	pool.Put("Aidil")
+	pool.Put(nil)
+
+	hits := int64(0)
+	if pool.Get() == "Aidil" {
+		hits++
+	}
+	if value := pool.Get(); value != "New" {
+		t.Fatalf("Get = %v", value)
+	}
+	stats := pool.Stats()
+	if stats != (PoolStats{Gets: 2, Puts: 1, Misses: 2 - hits}) || stats.Hits() != hits || stats.HitRate() != float64(hits)/2 {
+		t.Fatalf("stats = %+v, hits = %d", stats, hits)
+	}
+}
@@ -0,0 +1,70 @@
+package syncx
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// WritePrometheus menulis metric lock dalam format teks Prometheus (exposition format 0.0.4)
+// sehingga hasilnya dapat langsung di-scrape atau disimpan untuk dianalisis
+func WritePrometheus(w io.Writer, stats ...LockStats) error {
+	writer := bufio.NewWriter(w)
+
+	fmt.Fprintln(writer, "# HELP syncx_lock_acquisitions_total Jumlah akuisisi lock berdasarkan contention.")
+	fmt.Fprintln(writer, "# TYPE syncx_lock_acquisitions_total counter")
+	for _, stat := range stats {
+		labels := lockLabels(stat)
+		fmt.Fprintf(writer, "syncx_lock_acquisitions_total{%s,contended=\"true\"} %d\n", labels, stat.Contended)
+		fmt.Fprintf(writer, "syncx_lock_acquisitions_total{%s,contended=\"false\"} %d\n", labels, stat.Uncontended)
+	}
+
+	writeHistogram(writer, "syncx_lock_wait_seconds", "Waktu tunggu sebelum lock diperoleh.", stats,
+		func(stat LockStats) HistogramSnapshot { return stat.Wait })
+	writeHistogram(writer, "syncx_lock_hold_seconds", "Lama lock ditahan.", stats,
+		func(stat LockStats) HistogramSnapshot { return stat.Hold })
+
+	fmt.Fprintln(writer, "# HELP syncx_lock_slow_holds Jumlah hold lambat yang tersimpan.")
+	fmt.Fprintln(writer, "# TYPE syncx_lock_slow_holds gauge")
+	for _, stat := range stats {
+		fmt.Fprintf(writer, "syncx_lock_slow_holds{%s} %d\n", lockLabels(stat), len(stat.SlowHolds))
+	}
+
+	return writer.Flush()
+}
+
+func writeHistogram(writer io.Writer, name, help string, stats []LockStats, pick func(LockStats) HistogramSnapshot) {
+	fmt.Fprintf(writer, "# HELP %s %s\n", name, help)
+	fmt.Fprintf(writer, "# TYPE %s histogram\n", name)
+	for _, stat := range stats {
+		labels := lockLabels(stat)
+		histogram := pick(stat)
+		for _, bucket := range histogram.Buckets {
+			fmt.Fprintf(writer, "%s_bucket{%s,le=\"%s\"} %d\n", name, labels, seconds(bucket.UpperBound), bucket.Count)
+		}
+		fmt.Fprintf(writer, "%s_bucket{%s,le=\"+Inf\"} %d\n", name, labels, histogram.Count)
+		fmt.Fprintf(writer, "%s_sum{%s} %s\n", name, labels, seconds(histogram.Sum))
+		fmt.Fprintf(writer, "%s_count{%s} %d\n", name, labels, histogram.Count)
+	}
+}
+
+// lockLabels membentuk label lock dan mode
+func lockLabels(stat LockStats) string {
+	return fmt.Sprintf("lock=\"%s\",mode=\"%s\"", EscapeLabel(stat.Name), EscapeLabel(stat.Mode))
+}
+
+// labelEscaper hanya mengganti karakter yang wajib di-escape pada label value Prometheus
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// EscapeLabel meng-escape backslash, kutip dan newline pada value sesuai aturan label value
+// Prometheus. Karakter lain, termasuk non-ASCII, ditulis apa adanya karena formatnya UTF-8
+func EscapeLabel(value string) string {
+	return labelEscaper.Replace(value)
+}
+
+func seconds(duration time.Duration) string {
+	return strconv.FormatFloat(duration.Seconds(), 'g', -1, 64)
+}
@@ -0,0 +1,276 @@
+package syncx
+
+import (
+	"container/list"
+	"math/rand"
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// RWLocker adalah method yang dimiliki sync.RWMutex, sehingga sync.RWMutex dan semua varian
+// di file ini dapat saling menggantikan
+type RWLocker interface {
+	Lock()
+	Unlock()
+	RLock()
+	RUnlock()
+}
+
+var (
+	_ RWLocker = (*sync.RWMutex)(nil)
+	_ RWLocker = (*ReaderPreferringRWMutex)(nil)
+	_ RWLocker = (*FairRWMutex)(nil)
+	_ RWLocker = (*BigReaderRWMutex)(nil)
+)
+
+// ReaderPreferringRWMutex adalah RWMutex yang selalu mendahulukan reader: RLock langsung berhasil
+// selama tidak ada writer yang sedang memegang lock, meskipun ada writer yang sudah menunggu.
+// Latency read paling kecil, tetapi writer bisa kelaparan (starved) jika reader tidak pernah berhenti.
+// Zero value siap dipakai
+type ReaderPreferringRWMutex struct {
+	once    sync.Once
+	mutex   sync.Mutex
+	changed *sync.Cond // Dibangunkan ketika writing atau readers berubah
+	readers int
+	writing bool
+}
+
+func (mutex *ReaderPreferringRWMutex) init() {
+	mutex.once.Do(func() {
+		mutex.changed = sync.NewCond(&mutex.mutex)
+	})
+}
+
+// Lock mengunci untuk operasi write, menunggu sampai tidak ada reader maupun writer
+func (mutex *ReaderPreferringRWMutex) Lock() {
+	mutex.init()
+	mutex.mutex.Lock()
+	for mutex.writing || mutex.readers > 0 {
+		mutex.changed.Wait()
+	}
+	mutex.writing = true
+	mutex.mutex.Unlock()
+}
+
+// Unlock membuka write lock, panic jika tidak sedang dikunci untuk write
+func (mutex *ReaderPreferringRWMutex) Unlock() {
+	mutex.init()
+	mutex.mutex.Lock()
+	if !mutex.writing {
+		mutex.mutex.Unlock()
+		panic("syncx: unlock of unlocked ReaderPreferringRWMutex")
+	}
+	mutex.writing = false
+	mutex.mutex.Unlock()
+	mutex.changed.Broadcast()
+}
+
+// RLock mengunci untuk operasi read, hanya menunggu writer yang sedang memegang lock
+func (mutex *ReaderPreferringRWMutex) RLock() {
+	mutex.init()
+	mutex.mutex.Lock()
+	for mutex.writing {
+		mutex.changed.Wait()
+	}
+	mutex.readers++
+	mutex.mutex.Unlock()
+}
+
+// RUnlock membuka read lock, panic jika tidak ada reader
+func (mutex *ReaderPreferringRWMutex) RUnlock() {
+	mutex.init()
+	mutex.mutex.Lock()
+	if mutex.readers == 0 {
+		mutex.mutex.Unlock()
+		panic("syncx: runlock of unlocked ReaderPreferringRWMutex")
+	}
+	mutex.readers--
+	last := mutex.readers == 0
+	mutex.mutex.Unlock()
+	if last {
+		mutex.changed.Broadcast()
+	}
+}
+
+// FairRWMutex adalah RWMutex yang melayani permintaan sesuai urutan kedatangan (FIFO).
+// Reader yang datang setelah writer menunggu writer tersebut selesai, dan reader yang berurutan
+// di depan antrian dilayani bersamaan. Tidak ada reader maupun writer yang kelaparan.
+// Zero value siap dipakai
+type FairRWMutex struct {
+	mutex   sync.Mutex
+	readers int
+	writing bool
+	waiters list.List // Berisi *rwWaiter sesuai urutan kedatangan
+}
+
+type rwWaiter struct {
+	write bool
+	ready chan struct{} // Ditutup ketika lock sudah diberikan ke waiter
+}
+
+// Lock mengunci untuk operasi write
+func (mutex *FairRWMutex) Lock() {
+	mutex.mutex.Lock()
+	if !mutex.writing && mutex.readers == 0 && mutex.waiters.Len() == 0 {
+		mutex.writing = true
+		mutex.mutex.Unlock()
+		return
+	}
+	mutex.wait(true)
+}
+
+// Unlock membuka write lock, panic jika tidak sedang dikunci untuk write
+func (mutex *FairRWMutex) Unlock() {
+	mutex.mutex.Lock()
+	defer mutex.mutex.Unlock()
+	if !mutex.writing {
+		panic("syncx: unlock of unlocked FairRWMutex")
+	}
+	mutex.writing = false
+	mutex.notifyWaiters()
+}
+
+// RLock mengunci untuk operasi read. Selama masih ada antrian, reader baru selalu masuk
+// ke belakang antrian meskipun lock sedang dipegang reader lain
+func (mutex *FairRWMutex) RLock() {
+	mutex.mutex.Lock()
+	if !mutex.writing && mutex.waiters.Len() == 0 {
+		mutex.readers++
+		mutex.mutex.Unlock()
+		return
+	}
+	mutex.wait(false)
+}
+
+// RUnlock membuka read lock, panic jika tidak ada reader
+func (mutex *FairRWMutex) RUnlock() {
+	mutex.mutex.Lock()
+	defer mutex.mutex.Unlock()
+	if mutex.readers == 0 {
+		panic("syncx: runlock of unlocked FairRWMutex")
+	}
+	mutex.readers--
+	if mutex.readers == 0 {
+		mutex.notifyWaiters()
+	}
+}
+
+// wait memasukkan pemanggil ke antrian lalu menunggu giliran. Pemanggil harus memegang mutex.mutex,
+// yang dilepas sebelum menunggu
+func (mutex *FairRWMutex) wait(write bool) {
+	waiter := &rwWaiter{write: write, ready: make(chan struct{})}
+	mutex.waiters.PushBack(waiter)
+	mutex.mutex.Unlock()
+	<-waiter.ready
+}
+
+// notifyWaiters memberikan lock ke waiter dari depan antrian: satu writer, atau semua reader
+// yang berurutan sampai writer berikutnya
+func (mutex *FairRWMutex) notifyWaiters() {
+	for {
+		front := mutex.waiters.Front()
+		if front == nil || mutex.writing {
+			return
+		}
+		waiter := front.Value.(*rwWaiter)
+		if waiter.write {
+			if mutex.readers > 0 {
+				return
+			}
+			mutex.writing = true
+		} else {
+			mutex.readers++
+		}
+		mutex.waiters.Remove(front)
+		close(waiter.ready)
+	}
+}
+
+// readerShard adalah jumlah reader pada satu shard, diberi padding agar setiap shard
+// berada di cache line sendiri
+type readerShard struct {
+	readers atomic.Int64
+	_       [56]byte
+}
+
+// BigReaderRWMutex adalah RWMutex terdistribusi untuk workload yang hampir semuanya read.
+// Jumlah reader dipecah ke beberapa shard sebanyak GOMAXPROCS, sehingga reader di CPU berbeda
+// tidak berebut satu cache line seperti pada sync.RWMutex. Go tidak menyediakan nomor P untuk
+// kode biasa, sehingga shard dipilih secara acak dan RUnlock boleh mengurangi shard yang berbeda
+// dengan RLock; yang penting hanya jumlah seluruh shard. Sebagai gantinya, Lock jauh lebih mahal
+// karena harus menunggu jumlah reader di semua shard menjadi 0. Writer didahulukan: reader baru
+// mundur selama ada writer. Zero value siap dipakai
+type BigReaderRWMutex struct {
+	once    sync.Once
+	shards  []readerShard
+	mask    uint32
+	writer  sync.RWMutex // Dipegang writer, reader yang mundur menunggu di sini
+	writing atomic.Bool
+}
+
+func (mutex *BigReaderRWMutex) init() {
+	mutex.once.Do(func() {
+		size := 1
+		for size < runtime.GOMAXPROCS(0) {
+			size *= 2
+		}
+		mutex.shards = make([]readerShard, size)
+		mutex.mask = uint32(size - 1)
+	})
+}
+
+// shard memilih satu shard secara acak
+func (mutex *BigReaderRWMutex) shard() *readerShard {
+	return &mutex.shards[rand.Uint32()&mutex.mask]
+}
+
+// Lock mengunci untuk operasi write. Setelah writing ditandai, reader baru akan mundur,
+// sehingga Lock cukup menunggu reader yang sudah masuk selesai
+func (mutex *BigReaderRWMutex) Lock() {
+	mutex.init()
+	mutex.writer.Lock()
+	mutex.writing.Store(true)
+	for mutex.readers() != 0 {
+		runtime.Gosched()
+	}
+}
+
+// Unlock membuka write lock
+func (mutex *BigReaderRWMutex) Unlock() {
+	mutex.writing.Store(false)
+	mutex.writer.Unlock()
+}
+
+// RLock mengunci untuk operasi read. Jalur cepatnya hanya satu atomic add pada shard acak
+// dan satu atomic load
+func (mutex *BigReaderRWMutex) RLock() {
+	mutex.init()
+	for {
+		shard := mutex.shard()
+		shard.readers.Add(1)
+		if !mutex.writing.Load() {
+			return
+		}
+		// Ada writer: batalkan lalu tunggu writer selesai sebelum mencoba lagi
+		shard.readers.Add(-1)
+		mutex.writer.RLock()
+		mutex.writer.RUnlock()
+	}
+}
+
+// RUnlock membuka read lock
+func (mutex *BigReaderRWMutex) RUnlock() {
+	mutex.init()
+	mutex.shard().readers.Add(-1)
+}
+
+// readers menjumlahkan reader di semua shard. Shard yang dibaca sebelum RUnlock di shard lain
+// hanya membuat jumlahnya lebih besar, tidak pernah lebih kecil dari reader yang masih memegang lock
+func (mutex *BigReaderRWMutex) readers() int64 {
+	total := int64(0)
+	for i := range mutex.shards {
+		total += mutex.shards[i].readers.Load()
+	}
+	return total
+}
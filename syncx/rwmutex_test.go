@@ -0,0 +1,210 @@
+package syncx
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+// rwMutexes membuat setiap implementasi RWLocker yang dibandingkan
+var rwMutexes = []struct {
+	name string
+	new  func() RWLocker
+}{
+	{"sync", func() RWLocker { return &sync.RWMutex{} }},
+	{"reader", func() RWLocker { return &ReaderPreferringRWMutex{} }},
+	{"fair", func() RWLocker { return &FairRWMutex{} }},
+	{"bigreader", func() RWLocker { return &BigReaderRWMutex{} }},
+}
+
+// rwAccount meniru bank.Account dengan RWLocker yang bisa diganti
+type rwAccount struct {
+	mutex   RWLocker
+	balance int
+}
+
+func (account *rwAccount) AddBalance(amount int) {
+	account.mutex.Lock()
+	account.balance = account.balance + amount
+	account.mutex.Unlock()
+}
+
+func (account *rwAccount) GetBalance() int {
+	account.mutex.RLock()
+	balance := account.balance
+	account.mutex.RUnlock()
+	return balance
+}
+
+// TestRWLocker menjalankan pola TestRWMutex pada setiap implementasi: 100 goroutine yang
+// menambah dan membaca saldo tidak boleh kehilangan update, dan reader tidak boleh melihat
+// saldo yang turun
+func TestRWLocker(t *testing.T) {
+	for _, rw := range rwMutexes {
+		t.Run(rw.name, func(t *testing.T) {
+			account := &rwAccount{mutex: rw.new()}
+			group := sync.WaitGroup{}
+			for i := 0; i < 100; i++ {
+				group.Add(1)
+				go func() {
+					defer group.Done()
+					last := 0
+					for j := 0; j < 100; j++ {
+						account.AddBalance(1)
+						balance := account.GetBalance()
+						if balance < last {
+							t.Errorf("saldo turun dari %d ke %d", last, balance)
+						}
+						last = balance
+					}
+				}()
+			}
+			group.Wait()
+			if balance := account.GetBalance(); balance != 10000 {
+				t.Fatalf("saldo = %d", balance)
+			}
+		})
+	}
+}
+
+// TestRWLockerReaders memastikan beberapa reader dapat memegang lock bersamaan
+// dan writer menunggu sampai semua reader selesai
+func TestRWLockerReaders(t *testing.T) {
+	for _, rw := range rwMutexes {
+		t.Run(rw.name, func(t *testing.T) {
+			mutex := rw.new()
+			mutex.RLock()
+			mutex.RLock()
+
+			locked := make(chan struct{})
+			go func() {
+				mutex.Lock()
+				close(locked)
+			}()
+			mutex.RUnlock()
+			select {
+			case <-locked:
+				t.Fatal("writer mendapat lock ketika masih ada reader")
+			case <-time.After(20 * time.Millisecond):
+			}
+			mutex.RUnlock()
+			<-locked
+			mutex.Unlock()
+		})
+	}
+}
+
+// readerArrivesBehindWriter memegang read lock, menjalankan writer yang menunggu, lalu
+// menjalankan reader baru. Hasilnya true jika reader baru mendapat lock sebelum writer
+func readerArrivesBehindWriter(mutex RWLocker) bool {
+	mutex.RLock()
+
+	order := make(chan string, 2)
+	go func() {
+		mutex.Lock()
+		order <- "writer"
+		mutex.Unlock()
+	}()
+	time.Sleep(20 * time.Millisecond)
+	go func() {
+		mutex.RLock()
+		order <- "reader"
+		mutex.RUnlock()
+	}()
+	time.Sleep(20 * time.Millisecond)
+
+	mutex.RUnlock()
+	return <-order == "reader"
+}
+
+// TestRWLockerPolicy memastikan ReaderPreferringRWMutex mendahulukan reader baru, sedangkan
+// FairRWMutex dan BigReaderRWMutex membuat reader baru menunggu writer yang datang lebih dulu
+func TestRWLockerPolicy(t *testing.T) {
+	if !readerArrivesBehindWriter(&ReaderPreferringRWMutex{}) {
+		t.Error("ReaderPreferringRWMutex seharusnya mendahulukan reader")
+	}
+	if readerArrivesBehindWriter(&FairRWMutex{}) {
+		t.Error("FairRWMutex seharusnya melayani writer yang datang lebih dulu")
+	}
+	if readerArrivesBehindWriter(&BigReaderRWMutex{}) {
+		t.Error("BigReaderRWMutex seharusnya mendahulukan writer")
+	}
+}
+
+// TestFairRWMutexOrder memastikan FairRWMutex melayani writer dan reader sesuai urutan kedatangan
+func TestFairRWMutexOrder(t *testing.T) {
+	mutex := &FairRWMutex{}
+	mutex.Lock()
+
+	var order []string
+	orderMutex := sync.Mutex{}
+	record := func(name string) {
+		orderMutex.Lock()
+		order = append(order, name)
+		orderMutex.Unlock()
+	}
+
+	group := sync.WaitGroup{}
+	for _, name := range []string{"w1", "r1", "r2", "w2", "r3"} {
+		group.Add(1)
+		if name[0] == 'w' {
+			go func() {
+				defer group.Done()
+				mutex.Lock()
+				record(name)
+				time.Sleep(5 * time.Millisecond)
+				mutex.Unlock()
+			}()
+		} else {
+			go func() {
+				defer group.Done()
+				mutex.RLock()
+				record(name)
+				time.Sleep(5 * time.Millisecond)
+				mutex.RUnlock()
+			}()
+		}
+		// Memberi waktu agar goroutine sudah masuk antrian sebelum goroutine berikutnya
+		time.Sleep(5 * time.Millisecond)
+	}
+	mutex.Unlock()
+	group.Wait()
+
+	// r1 dan r2 dilayani bersamaan sehingga urutan keduanya bebas
+	got := fmt.Sprint(order)
+	if got != "[w1 r1 r2 w2 r3]" && got != "[w1 r2 r1 w2 r3]" {
+		t.Fatalf("urutan = %s", got)
+	}
+}
+
+// BenchmarkRWLocker mengulang campuran AddBalance/GetBalance dari TestRWMutex dengan 100 goroutine
+// pada beberapa persentase read. Setiap goroutine menjalankan b.N/100 operasi
+func BenchmarkRWLocker(b *testing.B) {
+	for _, readPercent := range []int{50, 90, 99} {
+		for _, rw := range rwMutexes {
+			b.Run(fmt.Sprintf("read%d/%s", readPercent, rw.name), func(b *testing.B) {
+				account := &rwAccount{mutex: rw.new()}
+				group := sync.WaitGroup{}
+				for i := 0; i < 100; i++ {
+					operations := b.N / 100
+					if i < b.N%100 {
+						operations++
+					}
+					group.Add(1)
+					go func() {
+						defer group.Done()
+						for j := 0; j < operations; j++ {
+							if j%100 < readPercent {
+								account.GetBalance()
+							} else {
+								account.AddBalance(1)
+							}
+						}
+					}()
+				}
+				group.Wait()
+			})
+		}
+	}
+}
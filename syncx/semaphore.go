@@ -0,0 +1,132 @@
+package syncx
+
+import (
+	"container/list"
+	"context"
+	"sync"
+)
+
+// Semaphore adalah weighted semaphore dengan antrian FIFO. Permintaan dengan bobot besar
+// tidak akan kelaparan (starved) oleh permintaan kecil yang datang belakangan, karena
+// selama masih ada antrian, permintaan baru selalu masuk ke belakang antrian
+type Semaphore struct {
+	size    int64
+	mutex   sync.Mutex
+	current int64
+	waiters list.List // Berisi *semaphoreWaiter sesuai urutan kedatangan
+}
+
+type semaphoreWaiter struct {
+	n     int64
+	ready chan struct{} // Ditutup ketika bobot n sudah diberikan ke waiter
+}
+
+// NewSemaphore membuat semaphore dengan total bobot size
+func NewSemaphore(size int64) *Semaphore {
+	return &Semaphore{size: size}
+}
+
+// Acquire mengambil bobot n, menunggu sampai tersedia atau context selesai.
+// Jika context selesai, tidak ada bobot yang diambil dan error dari context dikembalikan
+func (semaphore *Semaphore) Acquire(ctx context.Context, n int64) error {
+	done := ctx.Done()
+
+	semaphore.mutex.Lock()
+	select {
+	case <-done:
+		// Context sudah selesai sebelum menunggu, jangan mengambil bobot
+		semaphore.mutex.Unlock()
+		return ctx.Err()
+	default:
+	}
+	if semaphore.size-semaphore.current >= n && semaphore.waiters.Len() == 0 {
+		semaphore.current += n
+		semaphore.mutex.Unlock()
+		return nil
+	}
+	if n > semaphore.size {
+		// Permintaan tidak akan pernah terpenuhi, tunggu sampai context selesai
+		semaphore.mutex.Unlock()
+		<-done
+		return ctx.Err()
+	}
+
+	waiter := &semaphoreWaiter{n: n, ready: make(chan struct{})}
+	element := semaphore.waiters.PushBack(waiter)
+	semaphore.mutex.Unlock()
+
+	select {
+	case <-done:
+		semaphore.mutex.Lock()
+		select {
+		case <-waiter.ready:
+			// Bobot diberikan tepat setelah context selesai, kembalikan bobotnya
+			semaphore.current -= n
+			semaphore.notifyWaiters()
+		default:
+			front := semaphore.waiters.Front() == element
+			semaphore.waiters.Remove(element)
+			// Jika waiter ini berada di depan, waiter berikutnya mungkin sudah bisa dilayani
+			if front && semaphore.size > semaphore.current {
+				semaphore.notifyWaiters()
+			}
+		}
+		semaphore.mutex.Unlock()
+		return ctx.Err()
+	case <-waiter.ready:
+		return nil
+	}
+}
+
+// TryAcquire mengambil bobot n tanpa menunggu, mengembalikan false jika tidak tersedia
+func (semaphore *Semaphore) TryAcquire(n int64) bool {
+	semaphore.mutex.Lock()
+	defer semaphore.mutex.Unlock()
+	if semaphore.size-semaphore.current >= n && semaphore.waiters.Len() == 0 {
+		semaphore.current += n
+		return true
+	}
+	return false
+}
+
+// Release mengembalikan bobot n ke semaphore, panic jika melebihi bobot yang sedang diambil
+func (semaphore *Semaphore) Release(n int64) {
+	semaphore.mutex.Lock()
+	defer semaphore.mutex.Unlock()
+	semaphore.current -= n
+	if semaphore.current < 0 {
+		panic("syncx: semaphore released more than held")
+	}
+	semaphore.notifyWaiters()
+}
+
+// notifyWaiters membangunkan waiter dari depan antrian selama bobotnya tersedia.
+// Jika waiter terdepan belum bisa dilayani, waiter di belakangnya ikut menunggu (FIFO)
+func (semaphore *Semaphore) notifyWaiters() {
+	for {
+		front := semaphore.waiters.Front()
+		if front == nil {
+			return
+		}
+		waiter := front.Value.(*semaphoreWaiter)
+		if semaphore.size-semaphore.current < waiter.n {
+			return
+		}
+		semaphore.current += waiter.n
+		semaphore.waiters.Remove(front)
+		close(waiter.ready)
+	}
+}
+
+// Go mengambil bobot n lalu menjalankan function di goroutine baru, bobot dikembalikan
+// ketika function selesai. Jika context selesai sebelum bobot diperoleh, function tidak dijalankan
+func (semaphore *Semaphore) Go(ctx context.Context, n int64, function func()) error {
+	if err := semaphore.Acquire(ctx, n); err != nil {
+		return err
+	}
+	go func() {
+		defer semaphore.Release(n)
+		function()
+	}()
+	return nil
+}
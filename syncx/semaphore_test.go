@@ -0,0 +1,113 @@
+package syncx
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestSemaphoreLimit memastikan jumlah goroutine yang berjalan bersamaan tidak melebihi ukuran semaphore
+func TestSemaphoreLimit(t *testing.T) {
+	semaphore := NewSemaphore(5)
+	var running, maxRunning atomic.Int64
+	group := sync.WaitGroup{}
+
+	for i := 0; i < 50; i++ {
+		group.Add(1)
+		err := semaphore.Go(context.Background(), 1, func() {
+			defer group.Done()
+			now := running.Add(1)
+			for {
+				old := maxRunning.Load()
+				if now <= old || maxRunning.CompareAndSwap(old, now) {
+					break
+				}
+			}
+			time.Sleep(time.Millisecond)
+			running.Add(-1)
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	group.Wait()
+
+	if maxRunning.Load() > 5 {
+		t.Fatalf("goroutine bersamaan = %d, batas 5", maxRunning.Load())
+	}
+}
+
+// TestSemaphoreFIFO memastikan permintaan besar tidak dilewati oleh permintaan kecil yang datang belakangan
+func TestSemaphoreFIFO(t *testing.T) {
+	semaphore := NewSemaphore(10)
+	if !semaphore.TryAcquire(5) {
+		t.Fatal("TryAcquire(5) seharusnya berhasil")
+	}
+
+	acquired := make(chan int64, 2)
+	go func() {
+		semaphore.Acquire(context.Background(), 10)
+		acquired <- 10
+	}()
+	time.Sleep(10 * time.Millisecond)
+
+	// Bobot 1 sebenarnya masih tersedia, tetapi ada waiter besar di depan antrian
+	if semaphore.TryAcquire(1) {
+		t.Fatal("TryAcquire(1) seharusnya gagal selama ada waiter di antrian")
+	}
+	go func() {
+		semaphore.Acquire(context.Background(), 1)
+		acquired <- 1
+	}()
+	time.Sleep(10 * time.Millisecond)
+
+	semaphore.Release(5)
+	if first := <-acquired; first != 10 {
+		t.Fatalf("waiter pertama yang dilayani = %d, seharusnya 10", first)
+	}
+	semaphore.Release(10)
+	if second := <-acquired; second != 1 {
+		t.Fatalf("waiter kedua yang dilayani = %d, seharusnya 1", second)
+	}
+}
+
+// TestSemaphoreCancel memastikan waiter yang dibatalkan tidak mengambil bobot dan tidak memblokir antrian
+func TestSemaphoreCancel(t *testing.T) {
+	semaphore := NewSemaphore(2)
+	semaphore.TryAcquire(1)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := semaphore.Acquire(ctx, 2); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("err = %v", err)
+	}
+	if !semaphore.TryAcquire(1) {
+		t.Fatal("bobot yang tersisa seharusnya bisa diambil setelah waiter dibatalkan")
+	}
+}
+
+// BenchmarkSemaphore mengukur Acquire/Release pada Semaphore dengan banyak goroutine
+func BenchmarkSemaphore(b *testing.B) {
+	semaphore := NewSemaphore(4)
+	ctx := context.Background()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			semaphore.Acquire(ctx, 1)
+			semaphore.Release(1)
+		}
+	})
+}
+
+// BenchmarkChannelSemaphore mengukur pola semaphore klasik menggunakan buffered channel sebagai pembanding
+func BenchmarkChannelSemaphore(b *testing.B) {
+	semaphore := make(chan struct{}, 4)
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			semaphore <- struct{}{}
+			<-semaphore
+		}
+	})
+}
@@ -0,0 +1,141 @@
+package syncx
+
+import (
+	"fmt"
+	"reflect"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"unsafe"
+)
+
+// SeqLock menyimpan satu nilai kecil yang sering dibaca dan jarang ditulis. Writer menaikkan
+// sequence menjadi ganjil, menulis nilai, lalu menaikkannya lagi menjadi genap. Reader tidak
+// mengunci apa pun: reader membaca sequence, menyalin nilai, lalu membaca sequence lagi dan
+// mengulang jika sequence ganjil atau berubah. Berbeda dengan RLock, reader tidak menulis ke
+// memori bersama, sehingga cache line tidak berpindah-pindah antar CPU.
+//
+// Nilai disimpan sebagai word yang dibaca dan ditulis dengan atomic, sehingga bersih dari
+// race detector. Akibatnya T tidak boleh berisi pointer (termasuk string, slice, map, interface,
+// channel, dan func), karena garbage collector tidak mengenali pointer yang disalin sebagai angka.
+// Zero value siap dipakai dan berisi zero value T
+type SeqLock[T any] struct {
+	once     sync.Once
+	writer   sync.Mutex // Menyerialkan writer
+	sequence atomic.Uint64
+	words    []atomic.Uintptr
+	retries  atomic.Uint64
+}
+
+// wordSize adalah ukuran satu word dalam byte
+const wordSize = unsafe.Sizeof(uintptr(0))
+
+func (lock *SeqLock[T]) init() {
+	lock.once.Do(func() {
+		var value T
+		kind := reflect.TypeOf(&value).Elem()
+		if hasPointers(kind) {
+			panic(fmt.Sprintf("syncx: SeqLock tidak mendukung %s karena berisi pointer", kind))
+		}
+		lock.words = make([]atomic.Uintptr, wordCount[T]())
+	})
+}
+
+// wordCount mengembalikan jumlah word yang dibutuhkan untuk menyimpan T
+func wordCount[T any]() int {
+	var value T
+	return int((unsafe.Sizeof(value) + wordSize - 1) / wordSize)
+}
+
+// hasPointers memeriksa apakah nilai bertipe kind berisi pointer
+func hasPointers(kind reflect.Type) bool {
+	switch kind.Kind() {
+	case reflect.Array:
+		return kind.Len() > 0 && hasPointers(kind.Elem())
+	case reflect.Struct:
+		for i := 0; i < kind.NumField(); i++ {
+			if hasPointers(kind.Field(i).Type) {
+				return true
+			}
+		}
+		return false
+	case reflect.Pointer, reflect.UnsafePointer, reflect.String, reflect.Slice, reflect.Map,
+		reflect.Interface, reflect.Chan, reflect.Func:
+		return true
+	default:
+		return false
+	}
+}
+
+// Load mengembalikan salinan nilai yang konsisten tanpa mengunci. Selama ada writer,
+// Load menunggu dan mencoba lagi
+func (lock *SeqLock[T]) Load() T {
+	lock.init()
+	var buffer seqBuffer[T]
+	words := buffer.words(len(lock.words))
+	for {
+		before := lock.sequence.Load()
+		if before%2 == 0 {
+			for i := range lock.words {
+				words[i] = lock.words[i].Load()
+			}
+			if lock.sequence.Load() == before {
+				return buffer.value
+			}
+		}
+		lock.retries.Add(1)
+		runtime.Gosched()
+	}
+}
+
+// Store mengganti nilai
+func (lock *SeqLock[T]) Store(value T) {
+	lock.init()
+	lock.writer.Lock()
+	lock.store(value)
+	lock.writer.Unlock()
+}
+
+// Update mengganti nilai dengan hasil update(nilai saat ini) lalu mengembalikannya. Writer lain
+// menunggu sampai Update selesai, sehingga tidak ada perubahan yang hilang
+func (lock *SeqLock[T]) Update(update func(T) T) T {
+	lock.init()
+	lock.writer.Lock()
+	defer lock.writer.Unlock()
+	var buffer seqBuffer[T]
+	words := buffer.words(len(lock.words))
+	for i := range lock.words {
+		words[i] = lock.words[i].Load()
+	}
+	value := update(buffer.value)
+	lock.store(value)
+	return value
+}
+
+// Retries mengembalikan berapa kali Load harus mengulang karena bertabrakan dengan writer
+func (lock *SeqLock[T]) Retries() uint64 {
+	return lock.retries.Load()
+}
+
+// store menulis value di antara dua kenaikan sequence, pemanggil harus memegang lock.writer
+func (lock *SeqLock[T]) store(value T) {
+	buffer := seqBuffer[T]{value: value}
+	words := buffer.words(len(lock.words))
+	lock.sequence.Add(1)
+	for i := range lock.words {
+		lock.words[i].Store(words[i])
+	}
+	lock.sequence.Add(1)
+}
+
+// seqBuffer adalah tempat menyalin T per word. Field padding membuat buffer rata word
+// dan cukup besar untuk word terakhir T yang tidak penuh
+type seqBuffer[T any] struct {
+	value   T
+	padding uintptr
+}
+
+// words mengembalikan n word pertama buffer
+func (buffer *seqBuffer[T]) words(n int) []uintptr {
+	return unsafe.Slice((*uintptr)(unsafe.Pointer(buffer)), n)
+}
@@ -0,0 +1,123 @@
+package syncx
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+// pair adalah nilai yang invariant-nya mudah diperiksa: B selalu sama dengan -A.
+// C berukuran 4 byte sehingga word terakhir tidak penuh
+type pair struct {
+	A, B int64
+	C    int32
+}
+
+// TestSeqLock menjalankan writer dan reader bersamaan. Reader tidak boleh melihat nilai setengah
+// ditulis, dan tidak ada Update yang hilang
+func TestSeqLock(t *testing.T) {
+	lock := &SeqLock[pair]{}
+	var stop atomic.Bool
+	readers := sync.WaitGroup{}
+	for i := 0; i < 4; i++ {
+		readers.Add(1)
+		go func() {
+			defer readers.Done()
+			for !stop.Load() {
+				value := lock.Load()
+				if value.B != -value.A || int64(value.C) != value.A%1000 {
+					t.Errorf("nilai tidak konsisten: %+v", value)
+					return
+				}
+			}
+		}()
+	}
+
+	writers := sync.WaitGroup{}
+	for i := 0; i < 4; i++ {
+		writers.Add(1)
+		go func() {
+			defer writers.Done()
+			for j := 0; j < 1000; j++ {
+				lock.Update(func(value pair) pair {
+					value.A++
+					return pair{A: value.A, B: -value.A, C: int32(value.A % 1000)}
+				})
+			}
+		}()
+	}
+	writers.Wait()
+	stop.Store(true)
+	readers.Wait()
+
+	if value := lock.Load(); value.A != 4000 {
+		t.Fatalf("nilai akhir = %+v", value)
+	}
+	t.Logf("%d kali Load mengulang", lock.Retries())
+}
+
+// TestSeqLockStore memastikan zero value berisi zero value T dan Store mengganti nilai
+func TestSeqLockStore(t *testing.T) {
+	var lock SeqLock[[3]byte]
+	if lock.Load() != [3]byte{} {
+		t.Fatalf("zero value = %v", lock.Load())
+	}
+	lock.Store([3]byte{1, 2, 3})
+	if lock.Load() != [3]byte{1, 2, 3} {
+		t.Fatalf("nilai = %v", lock.Load())
+	}
+}
+
+// TestSeqLockPointer memastikan tipe yang berisi pointer ditolak
+func TestSeqLockPointer(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("SeqLock[string] seharusnya panic")
+		}
+	}()
+	var lock SeqLock[struct {
+		ID   int
+		Name string
+	}]
+	lock.Load()
+}
+
+// benchmarkReadMostly menjalankan read dengan sesekali write dari banyak goroutine
+func benchmarkReadMostly(b *testing.B, read func(), write func()) {
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			if i%100 == 0 {
+				write()
+			} else {
+				read()
+			}
+			i++
+		}
+	})
+}
+
+// BenchmarkSeqLock mengukur pair yang dibaca lewat SeqLock dengan 1 write per 100 operasi
+func BenchmarkSeqLock(b *testing.B) {
+	lock := &SeqLock[pair]{}
+	benchmarkReadMostly(b,
+		func() { lock.Load() },
+		func() { lock.Update(func(value pair) pair { value.A++; return value }) })
+}
+
+// BenchmarkSeqLockRWMutex adalah pembanding BenchmarkSeqLock dengan sync.RWMutex
+func BenchmarkSeqLockRWMutex(b *testing.B) {
+	mutex := sync.RWMutex{}
+	value := pair{}
+	benchmarkReadMostly(b,
+		func() {
+			mutex.RLock()
+			_ = value
+			mutex.RUnlock()
+		},
+		func() {
+			mutex.Lock()
+			value.A++
+			mutex.Unlock()
+		})
+}
@@ -0,0 +1,60 @@
+package syncx
+
+import (
+	"bytes"
+	"runtime"
+	"strconv"
+	"sync/atomic"
+	"time"
+)
+
+// LockState adalah keadaan sebuah lock pada satu saat, misalnya untuk ditampilkan di dashboard
+type LockState struct {
+	Name    string        // Nama lock
+	Held    bool          // true jika write lock sedang dipegang
+	Holder  int64         // ID goroutine pemegang write lock, 0 jika TrackHolder tidak aktif
+	HeldFor time.Duration // Lama write lock sudah dipegang
+	Readers int64         // Jumlah read lock yang sedang dipegang
+	Waiters int64         // Jumlah goroutine yang sedang menunggu lock
+}
+
+// owner mencatat pemegang write lock dengan atomic agar State bisa dibaca tanpa mengunci
+type owner struct {
+	goroutine atomic.Int64
+	since     atomic.Int64 // UnixNano saat lock diperoleh, 0 jika lock tidak dipegang
+}
+
+func (owner *owner) acquired(track bool) {
+	if track {
+		owner.goroutine.Store(goroutineID())
+	}
+	owner.since.Store(time.Now().UnixNano())
+}
+
+func (owner *owner) released() {
+	owner.since.Store(0)
+	owner.goroutine.Store(0)
+}
+
+func (owner *owner) state(name string) LockState {
+	state := LockState{Name: name}
+	if since := owner.since.Load(); since != 0 {
+		state.Held = true
+		state.Holder = owner.goroutine.Load()
+		state.HeldFor = time.Since(time.Unix(0, since))
+	}
+	return state
+}
+
+// goroutineID membaca ID goroutine saat ini dari baris pertama stack trace, "goroutine 12 [running]:".
+// Go sengaja tidak menyediakan API untuk ini, sehingga hanya dipakai untuk diagnosa
+func goroutineID() int64 {
+	var buffer [64]byte
+	line := buffer[:runtime.Stack(buffer[:], false)]
+	line = bytes.TrimPrefix(line, []byte("goroutine "))
+	if end := bytes.IndexByte(line, ' '); end >= 0 {
+		line = line[:end]
+	}
+	id, _ := strconv.ParseInt(string(line), 10, 64)
+	return id
+}
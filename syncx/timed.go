@@ -0,0 +1,74 @@
+package syncx
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// TimedMutex adalah mutex berbasis channel dengan kapasitas 1 yang mendukung timeout dan context.
+// Berbeda dengan sync.Mutex, pemanggil dapat berhenti menunggu sehingga bisa mundur (back off)
+// daripada terjebak deadlock. Zero value siap dipakai dan mengimplementasikan sync.Locker
+type TimedMutex struct {
+	once sync.Once
+	slot chan struct{} // Berisi satu token ketika mutex sedang dikunci
+}
+
+func (mutex *TimedMutex) init() {
+	mutex.once.Do(func() {
+		mutex.slot = make(chan struct{}, 1)
+	})
+}
+
+// Lock mengunci mutex, menunggu tanpa batas waktu seperti sync.Mutex
+func (mutex *TimedMutex) Lock() {
+	mutex.init()
+	mutex.slot <- struct{}{}
+}
+
+// Unlock membuka mutex, panic jika mutex tidak sedang dikunci
+func (mutex *TimedMutex) Unlock() {
+	mutex.init()
+	select {
+	case <-mutex.slot:
+	default:
+		panic("syncx: unlock of unlocked TimedMutex")
+	}
+}
+
+// TryLock mencoba mengunci mutex tanpa menunggu
+func (mutex *TimedMutex) TryLock() bool {
+	mutex.init()
+	select {
+	case mutex.slot <- struct{}{}:
+		return true
+	default:
+		return false
+	}
+}
+
+// LockContext mengunci mutex atau mengembalikan error dari context jika context selesai lebih dulu
+func (mutex *TimedMutex) LockContext(ctx context.Context) error {
+	mutex.init()
+	select {
+	case mutex.slot <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// TryLockFor mencoba mengunci mutex dalam batas waktu duration
+func (mutex *TimedMutex) TryLockFor(duration time.Duration) bool {
+	if mutex.TryLock() {
+		return true
+	}
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+	select {
+	case mutex.slot <- struct{}{}:
+		return true
+	case <-timer.C:
+		return false
+	}
+}
@@ -0,0 +1,83 @@
+package syncx
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// TestTimedMutex memastikan TimedMutex mengamankan counter seperti sync.Mutex
+func TestTimedMutex(t *testing.T) {
+	x := 0
+	var locker sync.Locker = &TimedMutex{}
+	group := sync.WaitGroup{}
+	for i := 0; i < 100; i++ {
+		group.Add(1)
+		go func() {
+			defer group.Done()
+			for j := 0; j < 100; j++ {
+				locker.Lock()
+				x = x + 1
+				locker.Unlock()
+			}
+		}()
+	}
+	group.Wait()
+	if x != 10000 {
+		t.Fatalf("counter = %d", x)
+	}
+}
+
+// TestTryLockFor memastikan TryLockFor menyerah setelah timeout dan berhasil setelah mutex dibuka
+func TestTryLockFor(t *testing.T) {
+	mutex := TimedMutex{}
+	mutex.Lock()
+
+	start := time.Now()
+	if mutex.TryLockFor(20 * time.Millisecond) {
+		t.Fatal("TryLockFor seharusnya gagal ketika mutex dikunci")
+	}
+	if elapsed := time.Since(start); elapsed < 20*time.Millisecond {
+		t.Fatalf("TryLockFor kembali terlalu cepat: %v", elapsed)
+	}
+
+	time.AfterFunc(10*time.Millisecond, mutex.Unlock)
+	if !mutex.TryLockFor(time.Second) {
+		t.Fatal("TryLockFor seharusnya berhasil setelah mutex dibuka")
+	}
+	mutex.Unlock()
+}
+
+// TestLockContext memastikan LockContext berhenti menunggu ketika context dibatalkan
+func TestLockContext(t *testing.T) {
+	mutex := TimedMutex{}
+	mutex.Lock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := mutex.LockContext(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("err = %v", err)
+	}
+
+	mutex.Unlock()
+	if err := mutex.LockContext(context.Background()); err != nil {
+		t.Fatalf("err = %v", err)
+	}
+	if mutex.TryLock() {
+		t.Fatal("TryLock seharusnya gagal ketika mutex dikunci")
+	}
+	mutex.Unlock()
+}
+
+// TestTimedMutexUnlockPanic memastikan Unlock pada mutex yang tidak dikunci menyebabkan panic
+func TestTimedMutexUnlockPanic(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("Unlock seharusnya panic")
+		}
+	}()
+	mutex := TimedMutex{}
+	mutex.Unlock()
+}
@@ -1,6 +1,8 @@
 package belajar_golang_goroutines
 
 import (
+	"belajar-golang-goroutines/timex"
+	"context"
 	"fmt"
 	"testing"
 	"time"
@@ -40,3 +42,22 @@ func TestTick(t *testing.T) {
 		fmt.Println(time)
 	}
 }
+
+// TestRateLimiter menguji timex.RateLimiter yang membatasi laju event seperti ticker,
+// tetapi mengizinkan burst 3 event di awal lalu 1 event setiap 500 milidetik
+func TestRateLimiter(t *testing.T) {
+	limiter := timex.NewRateLimiter(timex.Config{
+		Mode:     timex.TokenBucket,
+		Limit:    2,
+		Interval: 1 * time.Second,
+		Burst:    3,
+	})
+
+	// 6 event: 3 event pertama langsung berjalan, sisanya menunggu token terisi
+	for i := 0; i < 6; i++ {
+		if err := limiter.Wait(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+		fmt.Println("Event", i, time.Now())
+	}
+}
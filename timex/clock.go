@@ -0,0 +1,170 @@
+// Package timex berisi helper berbasis waktu (clock, ticker, rate limiter) yang dapat
+// diganti dengan clock palsu agar perilakunya bisa diuji secara deterministik
+package timex
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// Timer adalah abstraksi dari time.Timer
+type Timer interface {
+	C() <-chan time.Time // Channel yang menerima waktu ketika timer selesai
+	Stop() bool          // Menghentikan timer, true jika timer masih aktif
+}
+
+// Ticker adalah abstraksi dari time.Ticker
+type Ticker interface {
+	C() <-chan time.Time // Channel yang menerima waktu setiap periode
+	Stop()               // Menghentikan ticker
+}
+
+// Clock adalah sumber waktu yang dapat diinjeksi, gunakan Real untuk waktu sebenarnya
+type Clock interface {
+	Now() time.Time
+	NewTimer(duration time.Duration) Timer
+	NewTicker(period time.Duration) Ticker
+}
+
+// Real adalah Clock yang memakai package time
+var Real Clock = realClock{}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time { return time.Now() }
+
+func (realClock) NewTimer(duration time.Duration) Timer {
+	return realTimer{time.NewTimer(duration)}
+}
+
+func (realClock) NewTicker(period time.Duration) Ticker {
+	return realTicker{time.NewTicker(period)}
+}
+
+type realTimer struct{ timer *time.Timer }
+
+func (timer realTimer) C() <-chan time.Time { return timer.timer.C }
+func (timer realTimer) Stop() bool          { return timer.timer.Stop() }
+
+type realTicker struct{ ticker *time.Ticker }
+
+func (ticker realTicker) C() <-chan time.Time { return ticker.ticker.C }
+func (ticker realTicker) Stop()               { ticker.ticker.Stop() }
+
+// FakeClock adalah Clock yang hanya bergerak ketika Advance dipanggil.
+// Timer dan ticker yang dibuat darinya akan berbunyi sesuai waktu palsu tersebut
+type FakeClock struct {
+	mutex   sync.Mutex
+	cond    *sync.Cond
+	now     time.Time
+	waiters []*fakeWaiter
+}
+
+type fakeWaiter struct {
+	clock   *FakeClock
+	when    time.Time
+	period  time.Duration // 0 untuk timer, lebih dari 0 untuk ticker
+	channel chan time.Time
+}
+
+// NewFakeClock membuat FakeClock yang dimulai pada waktu start
+func NewFakeClock(start time.Time) *FakeClock {
+	clock := &FakeClock{now: start}
+	clock.cond = sync.NewCond(&clock.mutex)
+	return clock
+}
+
+// Now mengembalikan waktu palsu saat ini
+func (clock *FakeClock) Now() time.Time {
+	clock.mutex.Lock()
+	defer clock.mutex.Unlock()
+	return clock.now
+}
+
+// NewTimer membuat timer yang berbunyi setelah clock dimajukan sejauh duration
+func (clock *FakeClock) NewTimer(duration time.Duration) Timer {
+	return clock.add(duration, 0)
+}
+
+// NewTicker membuat ticker yang berbunyi setiap clock dimajukan sejauh period
+func (clock *FakeClock) NewTicker(period time.Duration) Ticker {
+	if period <= 0 {
+		panic("timex: non-positive interval for NewTicker")
+	}
+	return fakeTicker{clock.add(period, period)}
+}
+
+func (clock *FakeClock) add(duration, period time.Duration) *fakeWaiter {
+	clock.mutex.Lock()
+	defer clock.mutex.Unlock()
+	// Channel ber-buffer 1 seperti time.Timer, tick yang tidak dibaca akan dibuang
+	waiter := &fakeWaiter{clock: clock, when: clock.now.Add(duration), period: period, channel: make(chan time.Time, 1)}
+	if duration <= 0 {
+		waiter.channel <- clock.now
+		return waiter
+	}
+	clock.waiters = append(clock.waiters, waiter)
+	clock.cond.Broadcast()
+	return waiter
+}
+
+// Advance memajukan waktu sejauh duration dan membunyikan semua timer dan ticker
+// yang jatuh tempo secara berurutan
+func (clock *FakeClock) Advance(duration time.Duration) {
+	clock.mutex.Lock()
+	defer clock.mutex.Unlock()
+
+	target := clock.now.Add(duration)
+	for {
+		sort.Slice(clock.waiters, func(i, j int) bool {
+			return clock.waiters[i].when.Before(clock.waiters[j].when)
+		})
+		if len(clock.waiters) == 0 || clock.waiters[0].when.After(target) {
+			break
+		}
+		waiter := clock.waiters[0]
+		clock.now = waiter.when
+		select {
+		case waiter.channel <- waiter.when:
+		default:
+		}
+		if waiter.period > 0 {
+			waiter.when = waiter.when.Add(waiter.period)
+		} else {
+			clock.waiters = clock.waiters[1:]
+		}
+	}
+	clock.now = target
+}
+
+// BlockUntil menunggu sampai ada minimal n timer atau ticker aktif, berguna di test untuk
+// memastikan goroutine lain sudah mulai menunggu sebelum clock dimajukan
+func (clock *FakeClock) BlockUntil(n int) {
+	clock.mutex.Lock()
+	defer clock.mutex.Unlock()
+	for len(clock.waiters) < n {
+		clock.cond.Wait()
+	}
+}
+
+func (waiter *fakeWaiter) C() <-chan time.Time { return waiter.channel }
+
+func (waiter *fakeWaiter) Stop() bool {
+	clock := waiter.clock
+	clock.mutex.Lock()
+	defer clock.mutex.Unlock()
+	for i, active := range clock.waiters {
+		if active == waiter {
+			clock.waiters = append(clock.waiters[:i], clock.waiters[i+1:]...)
+			clock.cond.Broadcast()
+			return true
+		}
+	}
+	return false
+}
+
+type fakeTicker struct{ waiter *fakeWaiter }
+
+func (ticker fakeTicker) C() <-chan time.Time { return ticker.waiter.channel }
+func (ticker fakeTicker) Stop()               { ticker.waiter.Stop() }
@@ -0,0 +1,51 @@
+package timex
+
+import (
+	"testing"
+	"time"
+)
+
+// TestFakeClockTicker memastikan ticker palsu berbunyi sesuai waktu yang dimajukan
+func TestFakeClockTicker(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := NewFakeClock(start)
+	ticker := clock.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	for i := 1; i <= 3; i++ {
+		clock.Advance(time.Second)
+		if tick := <-ticker.C(); !tick.Equal(start.Add(time.Duration(i) * time.Second)) {
+			t.Fatalf("tick ke-%d = %v", i, tick)
+		}
+	}
+}
+
+// TestFakeClockTimer memastikan timer palsu hanya berbunyi sekali dan bisa dihentikan
+func TestFakeClockTimer(t *testing.T) {
+	clock := NewFakeClock(time.Unix(0, 0))
+	timer := clock.NewTimer(5 * time.Second)
+
+	clock.Advance(4 * time.Second)
+	select {
+	case <-timer.C():
+		t.Fatal("timer berbunyi terlalu cepat")
+	default:
+	}
+
+	clock.Advance(time.Second)
+	<-timer.C()
+	if timer.Stop() {
+		t.Fatal("Stop seharusnya false untuk timer yang sudah berbunyi")
+	}
+
+	stopped := clock.NewTimer(time.Second)
+	if !stopped.Stop() {
+		t.Fatal("Stop seharusnya true untuk timer yang masih aktif")
+	}
+	clock.Advance(time.Second)
+	select {
+	case <-stopped.C():
+		t.Fatal("timer yang dihentikan tidak boleh berbunyi")
+	default:
+	}
+}
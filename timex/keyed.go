@@ -0,0 +1,125 @@
+package timex
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// KeyedRateLimiter menyimpan satu RateLimiter per key (misalnya per user atau per IP) di dalam
+// sync.Map. Limiter yang tidak dipakai selama IdleTimeout dihapus oleh janitor berbasis ticker
+type KeyedRateLimiter[K comparable] struct {
+	config      Config
+	idleTimeout time.Duration
+	limiters    sync.Map // K -> *keyedEntry
+	ticker      Ticker
+	done        chan struct{}
+	closeOnce   sync.Once
+	stopped     sync.WaitGroup
+}
+
+// keyedEntry adalah limiter milik satu key. mutex menyatukan pembaruan lastUsed oleh Get dengan
+// pemeriksaan dan penghapusan oleh evict, sehingga entry yang sudah dikembalikan Get tidak bisa
+// dihapus berdasarkan lastUsed yang lama
+type keyedEntry struct {
+	limiter  *RateLimiter
+	mutex    sync.Mutex
+	lastUsed int64 // UnixNano dari clock milik config
+	removed  bool  // Sudah dihapus dari map oleh evict
+}
+
+// NewKeyedRateLimiter membuat KeyedRateLimiter yang membuat limiter baru dengan config yang sama
+// untuk setiap key. Janitor memeriksa limiter idle setiap idleTimeout/2, panggil Close untuk menghentikannya
+func NewKeyedRateLimiter[K comparable](config Config, idleTimeout time.Duration) *KeyedRateLimiter[K] {
+	if config.Clock == nil {
+		config.Clock = Real
+	}
+	keyed := &KeyedRateLimiter[K]{
+		config:      config,
+		idleTimeout: idleTimeout,
+		ticker:      config.Clock.NewTicker(max(idleTimeout/2, time.Millisecond)),
+		done:        make(chan struct{}),
+	}
+	keyed.stopped.Add(1)
+	go keyed.janitor()
+	return keyed
+}
+
+// Get mengembalikan limiter untuk key, membuatnya jika belum ada. Limiter yang dikembalikan
+// selalu limiter yang tersimpan di map, sehingga pemanggil berikutnya dengan key yang sama
+// memakai limiter yang sama
+func (keyed *KeyedRateLimiter[K]) Get(key K) *RateLimiter {
+	for {
+		value, ok := keyed.limiters.Load(key)
+		if !ok {
+			value, _ = keyed.limiters.LoadOrStore(key, &keyedEntry{limiter: NewRateLimiter(keyed.config)})
+		}
+		entry := value.(*keyedEntry)
+		entry.mutex.Lock()
+		// Janitor bisa menghapus entry di antara Load dan Lock di atas, ulangi dengan entry baru.
+		// Waktu diambil setelah Lock agar tidak lebih tua dari cutoff evict yang berjalan sesudahnya
+		if !entry.removed {
+			entry.lastUsed = keyed.config.Clock.Now().UnixNano()
+			entry.mutex.Unlock()
+			return entry.limiter
+		}
+		entry.mutex.Unlock()
+	}
+}
+
+// Allow memanggil Allow pada limiter milik key
+func (keyed *KeyedRateLimiter[K]) Allow(key K) bool {
+	return keyed.Get(key).Allow()
+}
+
+// Wait memanggil Wait pada limiter milik key
+func (keyed *KeyedRateLimiter[K]) Wait(ctx context.Context, key K) error {
+	return keyed.Get(key).Wait(ctx)
+}
+
+// Len mengembalikan jumlah key yang limiter-nya masih disimpan
+func (keyed *KeyedRateLimiter[K]) Len() int {
+	count := 0
+	keyed.limiters.Range(func(key, value any) bool {
+		count++
+		return true
+	})
+	return count
+}
+
+// Close menghentikan janitor, aman dipanggil lebih dari sekali
+func (keyed *KeyedRateLimiter[K]) Close() {
+	keyed.closeOnce.Do(func() {
+		close(keyed.done)
+		keyed.stopped.Wait()
+	})
+}
+
+// janitor berjalan di goroutine sendiri dan menghapus limiter idle setiap kali ticker berbunyi
+func (keyed *KeyedRateLimiter[K]) janitor() {
+	defer keyed.stopped.Done()
+	defer keyed.ticker.Stop()
+	for {
+		select {
+		case <-keyed.done:
+			return
+		case <-keyed.ticker.C():
+			// Memakai Now daripada waktu tick, karena tick yang tidak sempat dibaca akan dibuang
+			keyed.evict(keyed.config.Clock.Now())
+		}
+	}
+}
+
+func (keyed *KeyedRateLimiter[K]) evict(now time.Time) {
+	cutoff := now.Add(-keyed.idleTimeout).UnixNano()
+	keyed.limiters.Range(func(key, value any) bool {
+		entry := value.(*keyedEntry)
+		entry.mutex.Lock()
+		if entry.lastUsed <= cutoff {
+			entry.removed = true
+			keyed.limiters.CompareAndDelete(key, value)
+		}
+		entry.mutex.Unlock()
+		return true
+	})
+}
@@ -0,0 +1,254 @@
+package timex
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// Mode menentukan algoritma yang dipakai RateLimiter
+type Mode int
+
+const (
+	// TokenBucket mengisi token secara konstan sampai kapasitas Burst, setiap event memakai satu token
+	TokenBucket Mode = iota
+	// LeakyBucket meloloskan event dengan jarak yang rata (Interval/Limit), maksimal Burst event mengantri
+	LeakyBucket
+	// SlidingWindow membatasi maksimal Limit event pada setiap jendela waktu sepanjang Interval
+	SlidingWindow
+)
+
+// ErrLimitExceeded dikembalikan Wait ketika event tidak bisa dijadwalkan,
+// misalnya antrian leaky bucket penuh atau deadline context terlalu dekat
+var ErrLimitExceeded = errors.New("timex: rate limit exceeded")
+
+// Config adalah konfigurasi RateLimiter
+type Config struct {
+	Mode     Mode          // Algoritma yang dipakai
+	Limit    int           // Jumlah event yang diizinkan setiap Interval
+	Interval time.Duration // Panjang periode untuk Limit, default 1 detik
+	Burst    int           // Kapasitas token (TokenBucket) atau antrian (LeakyBucket), default Limit
+	Clock    Clock         // Sumber waktu, default Real
+}
+
+// RateLimiter membatasi laju event dengan mode token bucket, leaky bucket, atau sliding window.
+// Semua method aman dipanggil dari banyak goroutine
+type RateLimiter struct {
+	config   Config
+	emission time.Duration // Jarak antar event: Interval / Limit
+
+	mutex  sync.Mutex
+	tokens float64     // TokenBucket: token yang tersedia, bisa negatif karena Reserve
+	last   time.Time   // TokenBucket: waktu terakhir token dihitung
+	next   time.Time   // LeakyBucket: waktu paling awal event berikutnya boleh lolos
+	log    []time.Time // SlidingWindow: waktu event yang masih berada di dalam jendela
+}
+
+// NewRateLimiter membuat RateLimiter dari config, Limit harus lebih dari 0
+func NewRateLimiter(config Config) *RateLimiter {
+	if config.Limit <= 0 {
+		panic("timex: rate limiter limit must be positive")
+	}
+	if config.Interval <= 0 {
+		config.Interval = time.Second
+	}
+	if config.Burst <= 0 {
+		config.Burst = config.Limit
+	}
+	if config.Clock == nil {
+		config.Clock = Real
+	}
+	limiter := &RateLimiter{
+		config:   config,
+		emission: config.Interval / time.Duration(config.Limit),
+		tokens:   float64(config.Burst),
+		last:     config.Clock.Now(),
+	}
+	return limiter
+}
+
+// Reservation adalah hasil Reserve: izin untuk menjalankan satu event setelah Delay
+type Reservation struct {
+	OK       bool      // false jika event tidak bisa dijadwalkan sama sekali
+	At       time.Time // Waktu event boleh dijalankan
+	limiter  *RateLimiter
+	canceled bool
+}
+
+// Delay mengembalikan lama menunggu dari sekarang sampai event boleh dijalankan
+func (reservation *Reservation) Delay() time.Duration {
+	if !reservation.OK {
+		return 0
+	}
+	delay := reservation.At.Sub(reservation.limiter.config.Clock.Now())
+	if delay < 0 {
+		return 0
+	}
+	return delay
+}
+
+// Cancel mengembalikan kuota milik reservasi yang belum dipakai, sehingga event lain bisa memakainya
+func (reservation *Reservation) Cancel() {
+	if !reservation.OK || reservation.canceled {
+		return
+	}
+	reservation.canceled = true
+	reservation.limiter.cancel(reservation.At)
+}
+
+// Allow melaporkan apakah satu event boleh dijalankan sekarang, kuota hanya dipakai jika true
+func (limiter *RateLimiter) Allow() bool {
+	limiter.mutex.Lock()
+	defer limiter.mutex.Unlock()
+	now := limiter.config.Clock.Now()
+	at, ok := limiter.schedule(now, 0)
+	return ok && !at.After(now)
+}
+
+// Reserve memesan kuota untuk satu event dan mengembalikan kapan event boleh dijalankan
+func (limiter *RateLimiter) Reserve() *Reservation {
+	limiter.mutex.Lock()
+	defer limiter.mutex.Unlock()
+	now := limiter.config.Clock.Now()
+	at, ok := limiter.schedule(now, -1)
+	return &Reservation{OK: ok, At: at, limiter: limiter}
+}
+
+// Wait menunggu sampai satu event boleh dijalankan atau context selesai.
+// Jika deadline context lebih awal dari jadwal event, Wait langsung mengembalikan ErrLimitExceeded
+func (limiter *RateLimiter) Wait(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	reservation := limiter.Reserve()
+	if !reservation.OK {
+		return ErrLimitExceeded
+	}
+	delay := reservation.Delay()
+	if delay == 0 {
+		return nil
+	}
+	// Deadline context selalu memakai waktu sebenarnya, sehingga dibandingkan dengan lama delay
+	if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < delay {
+		reservation.Cancel()
+		return ErrLimitExceeded
+	}
+
+	timer := limiter.config.Clock.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C():
+		return nil
+	case <-ctx.Done():
+		reservation.Cancel()
+		return ctx.Err()
+	}
+}
+
+// schedule menghitung waktu paling awal event berikutnya boleh berjalan.
+// maxDelay 0 berarti hanya menerima jadwal saat ini (Allow), -1 berarti menerima jadwal di masa depan (Reserve).
+// Kuota hanya dipakai jika jadwal diterima
+func (limiter *RateLimiter) schedule(now time.Time, maxDelay time.Duration) (time.Time, bool) {
+	accept := func(at time.Time) bool {
+		return maxDelay < 0 || !at.After(now.Add(maxDelay))
+	}
+
+	switch limiter.config.Mode {
+	case LeakyBucket:
+		at := limiter.next
+		if at.Before(now) {
+			at = now
+		}
+		// Event yang sudah mengantri tidak boleh melebihi kapasitas bucket
+		if at.Sub(now) >= time.Duration(limiter.config.Burst)*limiter.emission || !accept(at) {
+			return at, false
+		}
+		limiter.next = at.Add(limiter.emission)
+		return at, true
+
+	case SlidingWindow:
+		limiter.pruneLog(now)
+		at := now
+		if len(limiter.log) >= limiter.config.Limit {
+			// Tunggu sampai event ke-Limit dari belakang keluar dari jendela
+			expire := limiter.log[len(limiter.log)-limiter.config.Limit].Add(limiter.config.Interval)
+			if expire.After(at) {
+				at = expire
+			}
+		}
+		if !accept(at) {
+			return at, false
+		}
+		limiter.log = append(limiter.log, at)
+		return at, true
+
+	default:
+		limiter.refill(now)
+		at := now
+		if limiter.tokens < 1 {
+			deficit := 1 - limiter.tokens
+			at = now.Add(time.Duration(deficit * float64(limiter.emission)))
+		}
+		if !accept(at) {
+			return at, false
+		}
+		limiter.tokens--
+		return at, true
+	}
+}
+
+// refill menambah token sesuai waktu yang berlalu sejak perhitungan terakhir
+func (limiter *RateLimiter) refill(now time.Time) {
+	elapsed := now.Sub(limiter.last)
+	if elapsed <= 0 {
+		return
+	}
+	limiter.last = now
+	limiter.tokens += float64(elapsed) / float64(limiter.emission)
+	if limiter.tokens > float64(limiter.config.Burst) {
+		limiter.tokens = float64(limiter.config.Burst)
+	}
+}
+
+// pruneLog membuang event yang sudah keluar dari jendela sliding window
+func (limiter *RateLimiter) pruneLog(now time.Time) {
+	start := now.Add(-limiter.config.Interval)
+	index := 0
+	for index < len(limiter.log) && !limiter.log[index].After(start) {
+		index++
+	}
+	limiter.log = limiter.log[index:]
+}
+
+// cancel mengembalikan kuota dari reservasi yang dijadwalkan pada at
+func (limiter *RateLimiter) cancel(at time.Time) {
+	limiter.mutex.Lock()
+	defer limiter.mutex.Unlock()
+	now := limiter.config.Clock.Now()
+	if !at.After(now) {
+		// Jadwalnya sudah lewat, kuota dianggap terpakai
+		return
+	}
+
+	switch limiter.config.Mode {
+	case LeakyBucket:
+		// Hanya reservasi terakhir yang bisa dikembalikan tanpa menggeser jadwal event lain
+		if limiter.next.Equal(at.Add(limiter.emission)) {
+			limiter.next = at
+		}
+	case SlidingWindow:
+		for i := len(limiter.log) - 1; i >= 0; i-- {
+			if limiter.log[i].Equal(at) {
+				limiter.log = append(limiter.log[:i], limiter.log[i+1:]...)
+				break
+			}
+		}
+	default:
+		limiter.refill(now)
+		limiter.tokens++
+		if limiter.tokens > float64(limiter.config.Burst) {
+			limiter.tokens = float64(limiter.config.Burst)
+		}
+	}
+}
@@ -0,0 +1,190 @@
+package timex
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// allowed menghitung berapa kali Allow berhasil dari n percobaan
+func allowed(limiter *RateLimiter, n int) int {
+	count := 0
+	for i := 0; i < n; i++ {
+		if limiter.Allow() {
+			count++
+		}
+	}
+	return count
+}
+
+// TestTokenBucket memastikan burst dapat dipakai sekaligus lalu token terisi sesuai rate
+func TestTokenBucket(t *testing.T) {
+	clock := NewFakeClock(time.Unix(0, 0))
+	limiter := NewRateLimiter(Config{Mode: TokenBucket, Limit: 10, Interval: time.Second, Burst: 5, Clock: clock})
+
+	if got := allowed(limiter, 10); got != 5 {
+		t.Fatalf("burst = %d, seharusnya 5", got)
+	}
+	clock.Advance(300 * time.Millisecond)
+	if got := allowed(limiter, 10); got != 3 {
+		t.Fatalf("setelah 300ms = %d, seharusnya 3", got)
+	}
+	clock.Advance(10 * time.Second)
+	if got := allowed(limiter, 10); got != 5 {
+		t.Fatalf("token tidak boleh melebihi burst, dapat %d", got)
+	}
+}
+
+// TestLeakyBucket memastikan event diloloskan dengan jarak rata dan antrian dibatasi Burst
+func TestLeakyBucket(t *testing.T) {
+	clock := NewFakeClock(time.Unix(0, 0))
+	limiter := NewRateLimiter(Config{Mode: LeakyBucket, Limit: 10, Interval: time.Second, Burst: 3, Clock: clock})
+
+	var delays []time.Duration
+	for i := 0; i < 4; i++ {
+		reservation := limiter.Reserve()
+		if !reservation.OK {
+			break
+		}
+		delays = append(delays, reservation.Delay())
+	}
+	expected := []time.Duration{0, 100 * time.Millisecond, 200 * time.Millisecond}
+	if len(delays) != len(expected) {
+		t.Fatalf("delays = %v, seharusnya %v", delays, expected)
+	}
+	for i := range expected {
+		if delays[i] != expected[i] {
+			t.Fatalf("delays = %v, seharusnya %v", delays, expected)
+		}
+	}
+	if limiter.Allow() {
+		t.Fatal("Allow seharusnya gagal selama antrian masih berisi")
+	}
+}
+
+// TestSlidingWindow memastikan maksimal Limit event pada setiap jendela Interval
+func TestSlidingWindow(t *testing.T) {
+	clock := NewFakeClock(time.Unix(0, 0))
+	limiter := NewRateLimiter(Config{Mode: SlidingWindow, Limit: 3, Interval: time.Second, Clock: clock})
+
+	if got := allowed(limiter, 5); got != 3 {
+		t.Fatalf("jendela pertama = %d, seharusnya 3", got)
+	}
+	clock.Advance(999 * time.Millisecond)
+	if limiter.Allow() {
+		t.Fatal("event masih berada di dalam jendela")
+	}
+	clock.Advance(time.Millisecond)
+	if got := allowed(limiter, 5); got != 3 {
+		t.Fatalf("jendela kedua = %d, seharusnya 3", got)
+	}
+}
+
+// TestWait memastikan Wait menunggu timer dari clock dan mengembalikan kuota ketika dibatalkan
+func TestWait(t *testing.T) {
+	clock := NewFakeClock(time.Unix(0, 0))
+	limiter := NewRateLimiter(Config{Mode: TokenBucket, Limit: 1, Interval: time.Second, Burst: 1, Clock: clock})
+	if err := limiter.Wait(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	result := make(chan error)
+	go func() { result <- limiter.Wait(context.Background()) }()
+	clock.BlockUntil(1)
+	clock.Advance(time.Second)
+	if err := <-result; err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() { result <- limiter.Wait(ctx) }()
+	clock.BlockUntil(1)
+	cancel()
+	if err := <-result; !errors.Is(err, context.Canceled) {
+		t.Fatalf("err = %v", err)
+	}
+	// Kuota reservasi yang dibatalkan dikembalikan, sehingga satu detik kemudian event boleh berjalan
+	clock.Advance(time.Second)
+	if !limiter.Allow() {
+		t.Fatal("kuota dari reservasi yang dibatalkan seharusnya dikembalikan")
+	}
+
+	ctx, cancel = context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+	if err := limiter.Wait(ctx); !errors.Is(err, ErrLimitExceeded) {
+		t.Fatalf("err = %v, seharusnya ErrLimitExceeded karena deadline lebih awal", err)
+	}
+}
+
+// TestKeyedRateLimiter memastikan setiap key punya limiter sendiri dan key idle dihapus janitor
+func TestKeyedRateLimiter(t *testing.T) {
+	clock := NewFakeClock(time.Unix(0, 0))
+	keyed := NewKeyedRateLimiter[string](Config{Limit: 1, Interval: time.Second, Clock: clock}, time.Minute)
+	defer keyed.Close()
+
+	if !keyed.Allow("aidil") || keyed.Allow("aidil") {
+		t.Fatal("key aidil seharusnya dibatasi 1 event")
+	}
+	if !keyed.Allow("budi") {
+		t.Fatal("key budi punya limiter sendiri")
+	}
+	if keyed.Len() != 2 {
+		t.Fatalf("len = %d", keyed.Len())
+	}
+
+	clock.Advance(45 * time.Second)
+	keyed.Get("budi")
+	clock.Advance(45 * time.Second)
+
+	deadline := time.Now().Add(time.Second)
+	for keyed.Len() != 1 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if keyed.Len() != 1 {
+		t.Fatalf("len = %d, key aidil seharusnya sudah dihapus", keyed.Len())
+	}
+}
+
+// TestKeyedRateLimiterEvictRace menjalankan evict terus-menerus sambil Get memakai key yang sudah
+// idle dan membuat key baru. Limiter yang dikembalikan Get harus selalu limiter yang tersimpan di map
+func TestKeyedRateLimiterEvictRace(t *testing.T) {
+	clock := NewFakeClock(time.Unix(1000, 0))
+	keyed := NewKeyedRateLimiter[int](Config{Limit: 1, Interval: time.Second, Clock: clock}, time.Minute)
+	defer keyed.Close()
+
+	// Separuh key sudah idle ketika evict mulai berjalan, sehingga evict bisa membaca lastUsed
+	// lama tepat ketika Get sedang memperbaruinya
+	for i := 0; i < 1000; i++ {
+		keyed.Get(i)
+	}
+	clock.Advance(2 * time.Minute)
+
+	// Cutoff tepat sebelum waktu clock: entry yang baru dipakai tidak dihapus,
+	// tetapi entry yang idle atau lastUsed-nya belum diisi akan dihapus
+	evictAt := clock.Now().Add(time.Minute - time.Nanosecond)
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		for {
+			select {
+			case <-done:
+				return
+			default:
+				keyed.evict(evictAt)
+			}
+		}
+	}()
+
+	for i := 0; i < 2000; i++ {
+		limiter := keyed.Get(i)
+		if value, ok := keyed.limiters.Load(i); !ok || value.(*keyedEntry).limiter != limiter {
+			close(done)
+			<-stopped
+			t.Fatalf("key %d: limiter dari Get tidak ada di map", i)
+		}
+	}
+	close(done)
+	<-stopped
+}
@@ -0,0 +1,63 @@
+package timex
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// TimerStats adalah statistik TimerGroup
+type TimerStats struct {
+	Outstanding int64 // Timer yang belum berbunyi dan belum dihentikan
+	Fired       int64 // Timer yang fungsinya sudah dijalankan
+	Stopped     int64 // Timer yang dihentikan sebelum berbunyi
+}
+
+// TimerGroup membuat timer seperti time.AfterFunc sambil menghitung timer yang masih menunggu,
+// berguna untuk mendeteksi timer yang lupa dihentikan. Zero value siap dipakai
+type TimerGroup struct {
+	outstanding atomic.Int64
+	fired       atomic.Int64
+	stopped     atomic.Int64
+}
+
+// GroupTimer adalah timer milik TimerGroup
+type GroupTimer struct {
+	group *TimerGroup
+	timer *time.Timer
+	done  atomic.Bool // Menjamin timer hanya dihitung sekali sebagai fired atau stopped
+}
+
+// AfterFunc menjalankan function di goroutine sendiri setelah duration, seperti time.AfterFunc
+func (group *TimerGroup) AfterFunc(duration time.Duration, function func()) *GroupTimer {
+	timer := &GroupTimer{group: group}
+	group.outstanding.Add(1)
+	timer.timer = time.AfterFunc(duration, func() {
+		if timer.done.CompareAndSwap(false, true) {
+			group.outstanding.Add(-1)
+			group.fired.Add(1)
+		}
+		function()
+	})
+	return timer
+}
+
+// Stop menghentikan timer, true jika timer dihentikan sebelum berbunyi
+func (timer *GroupTimer) Stop() bool {
+	if !timer.timer.Stop() {
+		return false
+	}
+	if timer.done.CompareAndSwap(false, true) {
+		timer.group.outstanding.Add(-1)
+		timer.group.stopped.Add(1)
+	}
+	return true
+}
+
+// Stats mengembalikan statistik timer saat ini
+func (group *TimerGroup) Stats() TimerStats {
+	return TimerStats{
+		Outstanding: group.outstanding.Load(),
+		Fired:       group.fired.Load(),
+		Stopped:     group.stopped.Load(),
+	}
+}
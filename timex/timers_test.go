@@ -0,0 +1,24 @@
+package timex
+
+import (
+	"testing"
+	"time"
+)
+
+// TestTimerGroup memastikan timer dihitung sekali sebagai fired atau stopped
+func TestTimerGroup(t *testing.T) {
+	group := TimerGroup{}
+	fired := make(chan struct{})
+	group.AfterFunc(time.Millisecond, func() { close(fired) })
+	stopped := group.AfterFunc(time.Hour, func() {})
+	pending := group.AfterFunc(time.Hour, func() {})
+	defer pending.Stop()
+
+	<-fired
+	if !stopped.Stop() || stopped.Stop() {
+		t.Fatal("Stop kedua seharusnya false")
+	}
+	if stats := group.Stats(); stats != (TimerStats{Outstanding: 1, Fired: 1, Stopped: 1}) {
+		t.Fatalf("stats = %+v", stats)
+	}
+}
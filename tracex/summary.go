@@ -0,0 +1,677 @@
+package tracex
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// Nomor event format trace Go 1.22 ke atas (lihat internal/trace/tracev2 di source Go).
+// Hanya event yang dipakai ringkasan yang diberi nama
+const (
+	evEventBatch          = 1
+	evStrings             = 4
+	evString              = 5
+	evFrequency           = 8
+	evProcsChange         = 9
+	evGoCreate            = 14
+	evGoCreateSyscall     = 15
+	evGoStart             = 16
+	evGoDestroy           = 17
+	evGoDestroySyscall    = 18
+	evGoStop              = 19
+	evGoBlock             = 20
+	evGoUnblock           = 21
+	evGoSyscallBegin      = 22
+	evGoSyscallEnd        = 23
+	evGoSyscallEndBlocked = 24
+	evGoStatus            = 25
+	evUserTaskBegin       = 40
+	evUserTaskEnd         = 41
+	evUserRegionBegin     = 42
+	evUserRegionEnd       = 43
+	evGoSwitch            = 45
+	evGoSwitchDestroy     = 46
+	evGoCreateBlocked     = 47
+	evGoStatusStack       = 48
+	evExperimentalBatch   = 49
+	evSync                = 50
+	evClockSnapshot       = 51
+	evEndOfGeneration     = 52
+)
+
+// eventArgs adalah jumlah argumen varint setiap event di batch M, termasuk selisih timestamp.
+// Nilai 0 berarti event tersebut tidak boleh muncul di batch M
+var eventArgs = [...]int{
+	9: 3, 10: 3, 11: 1, 12: 4, 13: 3, // proc
+	14: 4, 15: 2, 16: 3, 17: 1, 18: 1, 19: 3, 20: 3, 21: 4, 22: 3, 23: 1, 24: 1, 25: 4, // goroutine
+	26: 3, 27: 1, // stop the world
+	28: 2, 29: 3, 30: 2, 31: 2, 32: 2, 33: 3, 34: 2, 35: 2, 36: 1, 37: 2, 38: 2, // GC
+	39: 2, 40: 5, 41: 3, 42: 4, 43: 4, 44: 5, // anotasi task, region, dan log
+	45: 3, 46: 3, 47: 4, 48: 5, 51: 4,
+}
+
+// Status goroutine di event GoStatus
+const (
+	statusRunning = 2
+	statusSyscall = 3
+	statusWaiting = 4 // Status terbesar yang dikenal
+)
+
+// Versi format trace yang dapat dibaca, sesuai header "go 1.N trace". Format ini milik runtime
+// dan tidak dijamin stabil, sehingga versi di luar rentang ditolak dengan ErrUnsupportedVersion
+// daripada dibaca dengan salah. Perubahan format tanpa perubahan versi ditangkap oleh validasi
+// di readBatch, readEvents, dan handle yang menghasilkan ErrMalformed
+const (
+	minVersion = 22
+	maxVersion = 26
+)
+
+// ErrUnsupportedVersion dikembalikan Summarize ketika trace dibuat oleh versi Go yang formatnya
+// tidak dikenal. File trace-nya tetap dapat dibuka dengan go tool trace
+var ErrUnsupportedVersion = errors.New("tracex: versi format trace tidak didukung")
+
+// ErrMalformed dikembalikan Summarize ketika isi trace tidak cocok dengan format yang dikenal,
+// misalnya event yang melewati akhir batch atau merujuk string yang tidak ada. Ringkasan tidak
+// pernah dihasilkan dari trace yang gagal divalidasi
+var ErrMalformed = errors.New("tracex: isi trace tidak sesuai format")
+
+// malformed membuat error ErrMalformed dengan keterangan
+func malformed(format string, args ...any) error {
+	return fmt.Errorf("%w: "+format, append([]any{ErrMalformed}, args...)...)
+}
+
+// Blocked adalah lama goroutine tidak berjalan karena menunggu, dikelompokkan berdasarkan penyebab
+type Blocked struct {
+	Sync    time.Duration // sync.Mutex, sync.RWMutex, sync.WaitGroup, dan sync.Cond
+	Channel time.Duration // Kirim, terima, dan select
+	Syscall time.Duration // Di dalam system call
+	Other   time.Duration // time.Sleep, network, dan penyebab lain
+}
+
+// Total mengembalikan jumlah semua waktu tunggu
+func (blocked Blocked) Total() time.Duration {
+	return blocked.Sync + blocked.Channel + blocked.Syscall + blocked.Other
+}
+
+// RegionSummary adalah ringkasan semua region dengan nama yang sama. Waktu tunggu dan goroutine
+// yang dibuat dihitung untuk region terdalam yang sedang aktif di goroutine tersebut
+type RegionSummary struct {
+	Name              string
+	Count             int           // Jumlah region yang selesai
+	Duration          time.Duration // Total durasi region yang selesai
+	GoroutinesCreated int
+	Blocked
+}
+
+// TaskSummary adalah ringkasan semua task dengan nama yang sama
+type TaskSummary struct {
+	Name     string
+	Count    int           // Jumlah task yang selesai
+	Duration time.Duration // Total durasi task yang selesai
+}
+
+// Summary adalah ringkasan satu file trace
+type Summary struct {
+	Duration          time.Duration   // Dari event pertama sampai event terakhir
+	Procs             int             // GOMAXPROCS yang paling lama berlaku selama task, atau selama trace jika tidak ada task
+	GoroutinesCreated int             // Termasuk goroutine milik runtime
+	Blocked                           // Total seluruh goroutine
+	Regions           []RegionSummary // Terurut berdasarkan nama
+	Tasks             []TaskSummary   // Terurut berdasarkan nama
+}
+
+// Region mengembalikan ringkasan region bernama name, atau ringkasan kosong jika tidak ada
+func (summary Summary) Region(name string) RegionSummary {
+	for _, region := range summary.Regions {
+		if region.Name == name {
+			return region
+		}
+	}
+	return RegionSummary{Name: name}
+}
+
+// Summarize membaca trace dari runtime/trace (format Go 1.22 ke atas) dan meringkasnya.
+// Event dari semua M diurutkan berdasarkan timestamp, sehingga hasilnya berupa perkiraan
+// yang cukup untuk membandingkan dua trace, bukan validasi penuh seperti go tool trace
+func Summarize(r io.Reader) (Summary, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return Summary{}, err
+	}
+	version, err := readHeader(data)
+	if err != nil {
+		return Summary{}, err
+	}
+
+	reader := bytes.NewReader(data[16:])
+	strings := make(map[uint64]map[uint64]string)
+	generations := make(map[uint64][]batch)
+	frequency := uint64(0)
+	for reader.Len() > 0 {
+		gen, batch, err := readBatch(reader)
+		if err != nil {
+			return Summary{}, err
+		}
+		if len(batch.data) == 0 {
+			continue
+		}
+		switch batch.data[0] {
+		case evStrings:
+			if strings[gen] == nil {
+				strings[gen] = make(map[uint64]string)
+			}
+			if err := readStrings(batch.data[1:], strings[gen]); err != nil {
+				return Summary{}, err
+			}
+		case evFrequency, evSync:
+			if value := readFrequency(batch.data); value > 0 {
+				frequency = value
+			}
+		default:
+			// Batch stack dan sampel CPU tidak dibutuhkan ringkasan
+			if batch.data[0] >= evProcsChange {
+				generations[gen] = append(generations[gen], batch)
+			}
+		}
+	}
+	if frequency == 0 {
+		return Summary{}, fmt.Errorf("trace go 1.%d tidak berisi frekuensi timestamp", version)
+	}
+
+	order := make([]uint64, 0, len(generations))
+	for gen := range generations {
+		order = append(order, gen)
+	}
+	sort.Slice(order, func(i, j int) bool { return order[i] < order[j] })
+
+	summarizer := newSummarizer(frequency)
+	for _, gen := range order {
+		events, err := readEvents(generations[gen])
+		if err != nil {
+			return Summary{}, err
+		}
+		for _, event := range events {
+			if err := summarizer.handle(event, strings[gen]); err != nil {
+				return Summary{}, err
+			}
+		}
+	}
+	return summarizer.finish(), nil
+}
+
+// readHeader membaca versi dari header 16 byte "go 1.N trace\x00\x00\x00"
+func readHeader(data []byte) (int, error) {
+	if len(data) < 16 || !bytes.HasPrefix(data, []byte("go 1.")) || !bytes.HasSuffix(data[:16], []byte(" trace\x00\x00\x00")) {
+		return 0, errors.New("bukan file trace Go")
+	}
+	version, err := strconv.Atoi(string(data[5:7]))
+	if err != nil {
+		return 0, errors.New("bukan file trace Go")
+	}
+	if version < minVersion || version > maxVersion {
+		return 0, fmt.Errorf("%w: go 1.%d", ErrUnsupportedVersion, version)
+	}
+	return version, nil
+}
+
+// batch adalah kumpulan event dari satu M beserta timestamp awalnya
+type batch struct {
+	m    uint64
+	time uint64
+	data []byte
+}
+
+// readBatch membaca satu batch. Batch eksperimen dan penanda akhir generasi dikembalikan kosong
+func readBatch(reader *bytes.Reader) (uint64, batch, error) {
+	kind, err := reader.ReadByte()
+	if err != nil {
+		return 0, batch{}, err
+	}
+	if kind == evEndOfGeneration {
+		return 0, batch{}, nil
+	}
+	if kind != evEventBatch && kind != evExperimentalBatch {
+		return 0, batch{}, malformed("batch tidak dikenal: %d", kind)
+	}
+	if kind == evExperimentalBatch {
+		if _, err := reader.ReadByte(); err != nil {
+			return 0, batch{}, malformed("batch eksperimen terpotong")
+		}
+	}
+
+	var header [4]uint64 // generasi, M, timestamp, ukuran
+	for i := range header {
+		if header[i], err = binary.ReadUvarint(reader); err != nil {
+			return 0, batch{}, malformed("header batch rusak: %v", err)
+		}
+	}
+	if header[3] > uint64(reader.Len()) {
+		return 0, batch{}, malformed("batch terpotong")
+	}
+	data := make([]byte, header[3])
+	if _, err := io.ReadFull(reader, data); err != nil {
+		return 0, batch{}, malformed("batch terpotong: %v", err)
+	}
+	if kind == evExperimentalBatch {
+		return header[0], batch{}, nil
+	}
+	return header[0], batch{m: header[1], time: header[2], data: data}, nil
+}
+
+// readStrings membaca isi batch string: berulang [EvString, ID, panjang, byte]
+func readStrings(data []byte, table map[uint64]string) error {
+	for len(data) > 0 {
+		if data[0] != evString {
+			return malformed("event %d di batch string", data[0])
+		}
+		data = data[1:]
+		id, n := binary.Uvarint(data)
+		if n <= 0 {
+			return malformed("ID string rusak")
+		}
+		data = data[n:]
+		length, n := binary.Uvarint(data)
+		if n <= 0 || uint64(len(data)-n) < length {
+			return malformed("panjang string rusak")
+		}
+		data = data[n:]
+		table[id] = string(data[:length])
+		data = data[length:]
+	}
+	return nil
+}
+
+// readFrequency mengambil jumlah tick per detik dari batch frekuensi (sampai Go 1.24)
+// atau batch sync (Go 1.25 ke atas), 0 jika tidak ditemukan
+func readFrequency(data []byte) uint64 {
+	if data[0] == evSync {
+		data = data[1:]
+	}
+	for len(data) > 0 {
+		kind := data[0]
+		data = data[1:]
+		count := 0
+		switch kind {
+		case evFrequency:
+			frequency, _ := binary.Uvarint(data)
+			return frequency
+		case evClockSnapshot:
+			count = eventArgs[evClockSnapshot]
+		default:
+			return 0
+		}
+		for i := 0; i < count; i++ {
+			_, n := binary.Uvarint(data)
+			if n <= 0 {
+				return 0
+			}
+			data = data[n:]
+		}
+	}
+	return 0
+}
+
+// event adalah satu event dengan timestamp absolut dan argumen tanpa selisih timestamp
+type event struct {
+	time uint64
+	m    uint64
+	kind byte
+	args [4]uint64
+}
+
+// readEvents membaca semua event satu generasi lalu mengurutkannya berdasarkan timestamp.
+// Pengurutan stabil menjaga urutan asli event dari M yang sama. Event terakhir harus berakhir
+// tepat di akhir batch, sehingga jumlah argumen yang salah tidak lolos tanpa error
+func readEvents(batches []batch) ([]event, error) {
+	var events []event
+	for _, batch := range batches {
+		data := batch.data
+		now := batch.time
+		for len(data) > 0 {
+			kind := data[0]
+			if int(kind) >= len(eventArgs) || eventArgs[kind] == 0 {
+				return nil, malformed("event %d tidak dikenal", kind)
+			}
+			data = data[1:]
+			current := event{m: batch.m, kind: kind}
+			for i := 0; i < eventArgs[kind]; i++ {
+				value, n := binary.Uvarint(data)
+				if n <= 0 {
+					return nil, malformed("event %d melewati akhir batch M %d", kind, batch.m)
+				}
+				data = data[n:]
+				if i == 0 {
+					now += value
+				} else {
+					current.args[i-1] = value
+				}
+			}
+			current.time = now
+			events = append(events, current)
+		}
+	}
+	sort.SliceStable(events, func(i, j int) bool { return events[i].time < events[j].time })
+	return events, nil
+}
+
+// openRegion adalah region yang sudah dimulai tetapi belum selesai
+type openRegion struct {
+	name  string
+	start uint64
+}
+
+// openTask adalah task yang sudah dimulai tetapi belum selesai
+type openTask struct {
+	name  string
+	start uint64
+}
+
+// goroutineState adalah keadaan satu goroutine selama trace dibaca
+type goroutineState struct {
+	regions     []openRegion
+	blocked     bool
+	blockedAt   uint64
+	blockReason string
+	blockRegion string
+	syscall     bool
+	syscallAt   uint64
+	syscallIn   string
+}
+
+// region mengembalikan nama region terdalam yang aktif, kosong jika tidak ada
+func (state *goroutineState) region() string {
+	if len(state.regions) == 0 {
+		return ""
+	}
+	return state.regions[len(state.regions)-1].name
+}
+
+// summarizer menyusun Summary dari event yang sudah terurut
+type summarizer struct {
+	frequency  float64
+	started    bool
+	first      uint64
+	last       uint64
+	running    map[uint64]uint64 // M ke goroutine yang sedang berjalan di atasnya
+	goroutines map[uint64]*goroutineState
+	tasks      map[uint64]openTask
+	regions    map[string]*RegionSummary
+	taskTotals map[string]*TaskSummary
+	procs      int            // GOMAXPROCS yang sedang berlaku
+	procsAt    uint64         // Timestamp terakhir procs dihitung
+	procsTrace map[int]uint64 // Lama setiap nilai GOMAXPROCS berlaku selama trace
+	procsTask  map[int]uint64 // Lama setiap nilai GOMAXPROCS berlaku selama ada task
+	summary    Summary
+}
+
+func newSummarizer(frequency uint64) *summarizer {
+	return &summarizer{
+		frequency:  float64(frequency),
+		running:    make(map[uint64]uint64),
+		goroutines: make(map[uint64]*goroutineState),
+		tasks:      make(map[uint64]openTask),
+		regions:    make(map[string]*RegionSummary),
+		taskTotals: make(map[string]*TaskSummary),
+		procsTrace: make(map[int]uint64),
+		procsTask:  make(map[int]uint64),
+	}
+}
+
+// duration mengubah selisih tick menjadi time.Duration
+func (s *summarizer) duration(from, to uint64) time.Duration {
+	if to <= from {
+		return 0
+	}
+	return time.Duration(float64(to-from) * float64(time.Second) / s.frequency)
+}
+
+func (s *summarizer) goroutine(id uint64) *goroutineState {
+	state, ok := s.goroutines[id]
+	if !ok {
+		state = &goroutineState{}
+		s.goroutines[id] = state
+	}
+	return state
+}
+
+func (s *summarizer) region(name string) *RegionSummary {
+	region, ok := s.regions[name]
+	if !ok {
+		region = &RegionSummary{Name: name}
+		s.regions[name] = region
+	}
+	return region
+}
+
+// countProcs mencatat lama GOMAXPROCS saat ini berlaku sampai now. Dipanggil sebelum GOMAXPROCS
+// berubah dan sebelum task dimulai atau selesai
+func (s *summarizer) countProcs(now uint64) {
+	if s.procs > 0 && now > s.procsAt {
+		s.procsTrace[s.procs] += now - s.procsAt
+		if len(s.tasks) > 0 {
+			s.procsTask[s.procs] += now - s.procsAt
+		}
+	}
+	s.procsAt = now
+}
+
+// longestProcs mengembalikan GOMAXPROCS yang paling lama berlaku, nilai terbesar jika sama lama
+func longestProcs(durations map[int]uint64) int {
+	longest := 0
+	for procs, duration := range durations {
+		if duration > durations[longest] || duration == durations[longest] && procs > longest {
+			longest = procs
+		}
+	}
+	return longest
+}
+
+// addBlocked menambahkan waktu tunggu ke total dan ke region tempat goroutine menunggu
+func (s *summarizer) addBlocked(region string, add func(blocked *Blocked)) {
+	add(&s.summary.Blocked)
+	if region != "" {
+		add(&s.region(region).Blocked)
+	}
+}
+
+// unblock mengakhiri waktu tunggu goroutine, jika sedang menunggu
+func (s *summarizer) unblock(id uint64, now uint64) {
+	state, ok := s.goroutines[id]
+	if !ok || !state.blocked {
+		return
+	}
+	state.blocked = false
+	elapsed := s.duration(state.blockedAt, now)
+	s.addBlocked(state.blockRegion, func(blocked *Blocked) {
+		switch state.blockReason {
+		case "sync", "sync.(*Cond).Wait":
+			blocked.Sync += elapsed
+		case "chan send", "chan receive", "select":
+			blocked.Channel += elapsed
+		default:
+			blocked.Other += elapsed
+		}
+	})
+}
+
+// endSyscall mengakhiri system call goroutine, jika sedang berada di dalamnya
+func (s *summarizer) endSyscall(id uint64, now uint64) {
+	state, ok := s.goroutines[id]
+	if !ok || !state.syscall {
+		return
+	}
+	state.syscall = false
+	elapsed := s.duration(state.syscallAt, now)
+	s.addBlocked(state.syscallIn, func(blocked *Blocked) { blocked.Syscall += elapsed })
+}
+
+// lookup mengambil string yang dirujuk event. Rujukan ke string yang tidak ada berarti argumen
+// event dibaca dari posisi yang salah
+func lookup(strings map[uint64]string, ev event, id uint64) (string, error) {
+	value, ok := strings[id]
+	if !ok {
+		return "", malformed("event %d merujuk string %d yang tidak ada", ev.kind, id)
+	}
+	return value, nil
+}
+
+// handle memproses satu event, gagal jika argumennya tidak masuk akal
+func (s *summarizer) handle(ev event, strings map[uint64]string) error {
+	if !s.started {
+		s.started = true
+		s.first = ev.time
+	}
+	s.last = max(s.last, ev.time)
+	current, running := s.running[ev.m]
+
+	switch ev.kind {
+	case evProcsChange:
+		// GOMAXPROCS bisa diubah di dalam task dan dipulihkan sebelum task selesai, sehingga
+		// yang dilaporkan adalah nilai yang paling lama berlaku, bukan nilai terakhir atau tertinggi
+		s.countProcs(ev.time)
+		s.procs = int(ev.args[0])
+	case evGoCreate, evGoCreateBlocked:
+		s.summary.GoroutinesCreated++
+		if running {
+			if name := s.goroutine(current).region(); name != "" {
+				s.region(name).GoroutinesCreated++
+			}
+		}
+	case evGoCreateSyscall:
+		s.running[ev.m] = ev.args[0]
+	case evGoStart, evGoSwitch, evGoSwitchDestroy:
+		s.running[ev.m] = ev.args[0]
+		s.unblock(ev.args[0], ev.time)
+	case evGoStatus, evGoStatusStack:
+		// Dikirim di awal setiap generasi untuk goroutine yang sudah ada
+		status := ev.args[2]
+		if status > statusWaiting {
+			return malformed("status goroutine %d tidak dikenal", status)
+		}
+		if status == statusRunning || status == statusSyscall {
+			s.running[ev.args[1]] = ev.args[0]
+			s.unblock(ev.args[0], ev.time)
+		}
+	case evGoStop:
+		delete(s.running, ev.m)
+	case evGoDestroy, evGoDestroySyscall:
+		delete(s.running, ev.m)
+		if running {
+			s.endSyscall(current, ev.time)
+			delete(s.goroutines, current)
+		}
+	case evGoBlock:
+		reason, err := lookup(strings, ev, ev.args[0])
+		if err != nil {
+			return err
+		}
+		delete(s.running, ev.m)
+		if running {
+			state := s.goroutine(current)
+			state.blocked = true
+			state.blockedAt = ev.time
+			state.blockReason = reason
+			state.blockRegion = state.region()
+		}
+	case evGoUnblock:
+		s.unblock(ev.args[0], ev.time)
+	case evGoSyscallBegin:
+		if running {
+			state := s.goroutine(current)
+			state.syscall = true
+			state.syscallAt = ev.time
+			state.syscallIn = state.region()
+		}
+	case evGoSyscallEnd, evGoSyscallEndBlocked:
+		if running {
+			s.endSyscall(current, ev.time)
+		}
+		if ev.kind == evGoSyscallEndBlocked {
+			// Goroutine harus menunggu P lagi sebelum berjalan
+			delete(s.running, ev.m)
+		}
+	case evUserTaskBegin:
+		name, err := lookup(strings, ev, ev.args[2])
+		if err != nil {
+			return err
+		}
+		s.countProcs(ev.time)
+		s.tasks[ev.args[0]] = openTask{name: name, start: ev.time}
+	case evUserTaskEnd:
+		if task, ok := s.tasks[ev.args[0]]; ok {
+			s.countProcs(ev.time)
+			delete(s.tasks, ev.args[0])
+			total, ok := s.taskTotals[task.name]
+			if !ok {
+				total = &TaskSummary{Name: task.name}
+				s.taskTotals[task.name] = total
+			}
+			total.Count++
+			total.Duration += s.duration(task.start, ev.time)
+		}
+	case evUserRegionBegin:
+		name, err := lookup(strings, ev, ev.args[1])
+		if err != nil {
+			return err
+		}
+		if running {
+			state := s.goroutine(current)
+			state.regions = append(state.regions, openRegion{name: name, start: ev.time})
+		}
+	case evUserRegionEnd:
+		name, err := lookup(strings, ev, ev.args[1])
+		if err != nil {
+			return err
+		}
+		if !running {
+			break
+		}
+		state := s.goroutine(current)
+		// Region yang dimulai sebelum trace aktif tidak ada di stack dan diabaikan
+		for i := len(state.regions) - 1; i >= 0; i-- {
+			if state.regions[i].name == name {
+				region := s.region(name)
+				region.Count++
+				region.Duration += s.duration(state.regions[i].start, ev.time)
+				state.regions = state.regions[:i]
+				break
+			}
+		}
+	}
+	return nil
+}
+
+// finish menutup waktu tunggu yang masih berjalan di akhir trace, misalnya goroutine yang
+// deadlock, lalu mengembalikan Summary
+func (s *summarizer) finish() Summary {
+	for id := range s.goroutines {
+		s.unblock(id, s.last)
+		s.endSyscall(id, s.last)
+	}
+	s.summary.Duration = s.duration(s.first, s.last)
+	s.countProcs(s.last)
+	switch {
+	case len(s.procsTask) > 0:
+		s.summary.Procs = longestProcs(s.procsTask)
+	case len(s.procsTrace) > 0:
+		s.summary.Procs = longestProcs(s.procsTrace)
+	default:
+		s.summary.Procs = s.procs
+	}
+
+	for _, region := range s.regions {
+		s.summary.Regions = append(s.summary.Regions, *region)
+	}
+	sort.Slice(s.summary.Regions, func(i, j int) bool { return s.summary.Regions[i].Name < s.summary.Regions[j].Name })
+	for _, task := range s.taskTotals {
+		s.summary.Tasks = append(s.summary.Tasks, *task)
+	}
+	sort.Slice(s.summary.Tasks, func(i, j int) bool { return s.summary.Tasks[i].Name < s.summary.Tasks[j].Name })
+	return s.summary
+}
@@ -0,0 +1,35 @@
+// Package tracex membungkus runtime/trace agar skenario apa pun dapat direkam sebagai satu task,
+// lalu meringkas file trace menjadi angka (goroutine dibuat, waktu terblokir pada sync, channel,
+// dan syscall per region) sehingga pengaruh GOMAXPROCS dapat dibandingkan di dalam test
+package tracex
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"runtime/trace"
+)
+
+// Capture menjalankan fn dengan runtime/trace aktif dan menulis trace ke out. ctx milik fn
+// berisi task bernama name, sehingga region di dalamnya terkelompok di go tool trace.
+// Gagal jika trace sedang aktif, misalnya ketika test dijalankan dengan go test -trace
+func Capture(ctx context.Context, out io.Writer, name string, fn func(ctx context.Context)) error {
+	if err := trace.Start(out); err != nil {
+		return err
+	}
+	defer trace.Stop()
+
+	ctx, task := trace.NewTask(ctx, name)
+	defer task.End()
+	fn(ctx)
+	return nil
+}
+
+// Run sama seperti Capture, tetapi trace disimpan di memori lalu langsung diringkas
+func Run(ctx context.Context, name string, fn func(ctx context.Context)) (Summary, error) {
+	var buffer bytes.Buffer
+	if err := Capture(ctx, &buffer, name, fn); err != nil {
+		return Summary{}, err
+	}
+	return Summarize(&buffer)
+}
@@ -0,0 +1,156 @@
+package tracex
+
+import (
+	"belajar-golang-goroutines/bank"
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"runtime"
+	"runtime/trace"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// skipIfTracing melewati test jika go test dijalankan dengan -trace, karena hanya satu trace
+// yang boleh aktif
+func skipIfTracing(t *testing.T) {
+	if trace.IsEnabled() {
+		t.Skip("runtime/trace sudah aktif")
+	}
+}
+
+// run sama seperti Run, tetapi melewati test jika toolchain menulis format trace yang belum didukung
+func run(t *testing.T, name string, fn func(ctx context.Context)) Summary {
+	t.Helper()
+	summary, err := Run(context.Background(), name, fn)
+	if errors.Is(err, ErrUnsupportedVersion) {
+		t.Skip(err)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	return summary
+}
+
+// transfers menjalankan dua transfer searah secara bersamaan, sehingga transfer kedua
+// menunggu "lock user1" selama transfer pertama memegang rekening
+func transfers(ctx context.Context, work time.Duration) {
+	user1 := &bank.UserBalance{Name: "Aidil", Balance: 1000000}
+	user2 := &bank.UserBalance{Name: "Budi", Balance: 1000000}
+	group := sync.WaitGroup{}
+	trace.WithRegion(ctx, "spawn", func() {
+		for i := 0; i < 2; i++ {
+			group.Add(1)
+			go func() {
+				defer group.Done()
+				bank.TransferContext(ctx, io.Discard, user1, user2, 100000, work)
+			}()
+		}
+	})
+
+	done := make(chan struct{})
+	go func() {
+		group.Wait()
+		close(done)
+	}()
+	trace.WithRegion(ctx, "wait", func() { <-done })
+}
+
+// TestSummarize memastikan waktu tunggu lock, channel, dan goroutine yang dibuat tercatat
+// pada region yang benar
+func TestSummarize(t *testing.T) {
+	skipIfTracing(t)
+	work := 10 * time.Millisecond
+	summary := run(t, "transfers", func(ctx context.Context) { transfers(ctx, work) })
+
+	if lock := summary.Region("lock user1"); lock.Count != 2 || lock.Sync < work {
+		t.Fatalf("lock user1 = %+v", lock)
+	}
+	if transfer := summary.Region("transfer"); transfer.Count != 2 || transfer.Other < 4*work {
+		t.Fatalf("transfer = %+v", transfer)
+	}
+	if spawn := summary.Region("spawn"); spawn.GoroutinesCreated != 2 {
+		t.Fatalf("spawn = %+v", spawn)
+	}
+	if wait := summary.Region("wait"); wait.Channel < 3*work {
+		t.Fatalf("wait = %+v", wait)
+	}
+	if len(summary.Tasks) != 1 || summary.Tasks[0].Name != "transfers" || summary.Tasks[0].Count != 1 {
+		t.Fatalf("tasks = %+v", summary.Tasks)
+	}
+	if summary.GoroutinesCreated < 3 || summary.Sync < work || summary.Duration < 4*work {
+		t.Fatalf("summary = %+v", summary)
+	}
+}
+
+// TestCompareGomaxprocs merekam skenario yang sama dengan GOMAXPROCS berbeda. Nilai waktu tunggu
+// bergantung pada mesin sehingga hanya dicatat, yang diperiksa adalah GOMAXPROCS di trace
+func TestCompareGomaxprocs(t *testing.T) {
+	skipIfTracing(t)
+	previous := runtime.GOMAXPROCS(-1)
+	defer runtime.GOMAXPROCS(previous)
+
+	for _, procs := range []int{1, 4} {
+		runtime.GOMAXPROCS(procs)
+		summary := run(t, "transfers", func(ctx context.Context) { transfers(ctx, time.Millisecond) })
+		if summary.Procs != procs {
+			t.Fatalf("procs = %d, seharusnya %d", summary.Procs, procs)
+		}
+		lock := summary.Region("lock user1")
+		t.Logf("GOMAXPROCS %d: durasi %s, sync %s, channel %s, lock user1 %s",
+			procs, summary.Duration, summary.Sync, summary.Channel, lock.Sync)
+	}
+}
+
+// TestCaptureActive memastikan Capture gagal jika trace lain sedang aktif
+func TestCaptureActive(t *testing.T) {
+	skipIfTracing(t)
+	err := Capture(context.Background(), io.Discard, "luar", func(ctx context.Context) {
+		if err := Capture(ctx, io.Discard, "dalam", func(context.Context) {}); err == nil {
+			t.Error("Capture di dalam Capture seharusnya gagal")
+		}
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestSummarizeInvalid memastikan file yang bukan trace atau versi lama ditolak
+func TestSummarizeInvalid(t *testing.T) {
+	for input, expected := range map[string]string{
+		"bukan trace":                   "bukan file trace",
+		"go 1.21 trace\x00\x00\x00":     "tidak didukung: go 1.21",
+		"go 1.23 trace\x00\x00\x00\x09": "batch tidak dikenal",
+	} {
+		_, err := Summarize(bytes.NewReader([]byte(input)))
+		if err == nil || !strings.Contains(err.Error(), expected) {
+			t.Fatalf("%q: err = %v, seharusnya berisi %q", input, err, expected)
+		}
+	}
+	if _, err := Summarize(strings.NewReader("go 1.99 trace\x00\x00\x00")); !errors.Is(err, ErrUnsupportedVersion) {
+		t.Fatalf("err = %v, seharusnya ErrUnsupportedVersion", err)
+	}
+}
+
+// TestSummarizeMalformed memastikan batch yang isinya tidak cocok dengan ukuran yang dinyatakan,
+// misalnya karena format event berubah, ditolak dengan ErrMalformed
+func TestSummarizeMalformed(t *testing.T) {
+	const header = "go 1.23 trace\x00\x00\x00"
+	const frequency = "\x01\x01\x00\x01\x02\x08\x01"
+	for input, expected := range map[string]string{
+		// Ukuran batch 5 byte, tetapi hanya tersisa 2 byte
+		header + "\x01\x01\x00\x01\x05\x09\x01": "batch terpotong",
+		// ProcsChange butuh 3 argumen, batch berakhir setelah argumen pertama
+		header + frequency + "\x01\x01\x00\x01\x02\x09\x01": "event 9 melewati akhir batch",
+		// UserRegionBegin merujuk string 7 yang tidak ada
+		header + frequency + "\x01\x01\x00\x01\x05\x2a\x01\x01\x07\x00": "merujuk string 7",
+	} {
+		_, err := Summarize(strings.NewReader(input))
+		if !errors.Is(err, ErrMalformed) || !strings.Contains(err.Error(), expected) {
+			t.Fatalf("%q: err = %v, seharusnya ErrMalformed berisi %q", input, err, expected)
+		}
+	}
+}
@@ -2,28 +2,14 @@
 package belajar_golang_goroutines
 
 import (
+	"belajar-golang-goroutines/demo"
 	"fmt"
+	"os"
 	"sync"
 	"testing"
 	"time"
 )
 
-// RunAsynchronous adalah fungsi yang dijalankan secara asynchronous
-// menggunakan WaitGroup untuk sinkronisasi
-// Parameter:
-// - group: pointer ke sync.WaitGroup untuk mengelola goroutine
-func RunAsynchronous(group *sync.WaitGroup) {
-	// Pastikan group.Done() dipanggil ketika fungsi selesai
-	defer group.Done()
-
-	// Tambahkan counter goroutine ke WaitGroup
-	group.Add(1)
-
-	// Cetak pesan dan tunggu 1 detik untuk simulasi proses
-	fmt.Println("Hello")
-	time.Sleep(1 * time.Second)
-}
-
 // TestWaitGroup adalah fungsi test untuk mendemonstrasikan penggunaan WaitGroup
 // dalam mengelola multiple goroutines
 func TestWaitGroup(t *testing.T) {
@@ -32,7 +18,10 @@ func TestWaitGroup(t *testing.T) {
 
 	// Jalankan 100 goroutine secara bersamaan
 	for i := 0; i < 100; i++ {
-		go RunAsynchronous(group)
+		// Tambahkan counter goroutine ke WaitGroup sebelum goroutine dijalankan
+		group.Add(1)
+		// Cetak pesan dan tunggu 1 detik untuk simulasi proses
+		go demo.RunAsynchronous(group, os.Stdout, 1*time.Second)
 	}
 
 	// Tunggu semua goroutine selesai